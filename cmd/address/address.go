@@ -1,19 +1,20 @@
 package address
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdAddress returns new initialized instance of address sub command
-func NewCmdAddress() *cobra.Command {
+func NewCmdAddress(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "address",
 		Short: "Address Management",
 	}
-	cmd.AddCommand(NewCmdAddressCreate())
-	cmd.AddCommand(NewCmdAddressGet())
-	cmd.AddCommand(NewCmdAddressList())
-	cmd.AddCommand(NewCmdAddressUpdate())
-	cmd.AddCommand(NewCmdAddressDelete())
+	cmd.AddCommand(NewCmdAddressCreate(f))
+	cmd.AddCommand(NewCmdAddressGet(f))
+	cmd.AddCommand(NewCmdAddressList(f))
+	cmd.AddCommand(NewCmdAddressUpdate(f))
+	cmd.AddCommand(NewCmdAddressDelete(f))
 	return cmd
 }
@@ -1,12 +1,9 @@
 package address
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/spf13/cobra"
@@ -14,30 +11,22 @@ import (
 )
 
 // NewCmdAddressCreate returns new initialized instance of create sub command
-func NewCmdAddressCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdAddressCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create <email>",
 		Short: "Create a new address for a given user",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			email := args[0]
-			ctx := context.Background()
 			users, err := client.GetUsers(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			userMap := make(map[string]string, 0)
@@ -48,23 +37,21 @@ func NewCmdAddressCreate() *cobra.Command {
 			}
 
 			if _, ok := userMap[email]; !ok {
-				fmt.Fprintf(os.Stderr, "Email %s did not match any users\n", email)
-				os.Exit(1)
+				return fmt.Errorf("email %s did not match any users", email)
 			}
 
 			req, err := promptCreateAddress()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 			req.UserID = userMap[email]
 
 			addr, err := client.CreateAddress(ctx, req)
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				fmt.Fprintf(os.Stderr, "error creating address: %+v", errors.Unwrap(err))
+				return fmt.Errorf("error creating address: %w", err)
 			}
-			showAddress(addr)
+			showAddress(f.IOStreams.Out, addr)
+			return nil
 		},
 	}
 	return cmd
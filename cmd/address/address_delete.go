@@ -1,52 +1,47 @@
 package address
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdAddressDelete returns new initialized instance of the delete sub command
-func NewCmdAddressDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdAddressDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <address_id>",
 		Short: "Delete an address by id",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			addrID := args[0]
 			if !cmdvalidate.IsValidUUID(addrID) {
-				fmt.Fprintf(os.Stderr, "address_id %q is not a valid v4 uuid\n", addrID)
-				os.Exit(1)
+				return fmt.Errorf("address_id %q is not a valid v4 uuid", addrID)
 			}
 
-			ctx := context.Background()
+			if err := f.Confirm(yes, "delete address %s", addrID); err != nil {
+				return err
+			}
 			err = client.DeleteAddress(ctx, addrID)
-			if err == eclient.ErrAddressNotFound {
-				fmt.Fprintf(os.Stderr, "address %q not found\n", addrID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrAddressNotFound) {
+				return fmt.Errorf("address %q not found", addrID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
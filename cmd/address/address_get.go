@@ -1,84 +1,61 @@
 package address
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"io"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdAddressGet returns new initialized instance of the get sub command
-func NewCmdAddressGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdAddressGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get <address_id>",
 		Short: "Get address by id",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// address id
 			addrID := args[0]
 			if !cmdvalidate.IsValidUUID(addrID) {
-				fmt.Fprintf(os.Stderr, "address_id value %q is not a valid v4 uuid\n", addrID)
-				os.Exit(1)
+				return fmt.Errorf("address_id value %q is not a valid v4 uuid", addrID)
 			}
 
-			ctx := context.Background()
 			addr, err := client.GetAddress(ctx, addrID)
-			if err == eclient.ErrAddressNotFound {
-				fmt.Fprintf(os.Stderr, "address id %s not found\n", addrID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrAddressNotFound) {
+				return fmt.Errorf("address id %s not found", addrID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			showAddress(addr)
+			return out.Print(f.IOStreams.Out, addr, addressRecord(addr))
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
 
-func showAddress(v *eclient.Address) {
-	addr2 := "-"
-	if v.Addr2 != nil {
-		addr2 = *v.Addr2
-	}
-	county := "-"
-	if v.County != nil {
-		county = *v.County
-	}
-	format := "%v\t%v\t\n"
-	tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, format, "Address ID:", v.ID)
-	fmt.Fprintf(tw, format, "User ID:", v.UserID)
-	fmt.Fprintf(tw, format, "Type:", v.Typ)
-	fmt.Fprintf(tw, format, "Contact Name:", v.ContactName)
-	fmt.Fprintf(tw, format, "Address 1:", v.Addr1)
-	fmt.Fprintf(tw, format, "Address 2:", addr2)
-	fmt.Fprintf(tw, format, "City:", v.City)
-	fmt.Fprintf(tw, format, "County:", county)
-	fmt.Fprintf(tw, format, "Postcode:", v.Postcode)
-	fmt.Fprintf(tw, format, "Country Code:", v.CountryCode)
-	fmt.Fprintf(tw, format, "Created",
-		v.Created.In(location).Format(timeDisplayFormat))
-	fmt.Fprintf(tw, format, "Modified",
-		v.Modified.In(location).Format(timeDisplayFormat))
-	tw.Flush()
+func showAddress(w io.Writer, v *eclient.Address) {
+	addressRecord(v).Write(w)
+}
+
+func addressRecord(v *eclient.Address) *output.Table {
+	t := output.NewRecord("Address ID", "User ID", "Type", "Contact Name",
+		"Address 1", "Address 2", "City", "County", "Postcode",
+		"Country Code", "Created", "Modified")
+	t.Row(v.ID, v.UserID, v.Typ, v.ContactName, v.Addr1, v.Addr2, v.City,
+		v.County, v.Postcode, v.CountryCode, v.Created, v.Modified)
+	return t
 }
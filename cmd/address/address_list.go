@@ -1,56 +1,31 @@
 package address
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v", "Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdAddressList returns new initialized instance of the list sub command
-func NewCmdAddressList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdAddressList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list <email>",
 		Short: "list addressess for a user",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			email := args[0]
-			ctx := context.Background()
 			users, err := client.GetUsers(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			userMap := make(map[string]string, 0)
@@ -61,43 +36,24 @@ func NewCmdAddressList() *cobra.Command {
 			}
 
 			if _, ok := userMap[email]; !ok {
-				fmt.Fprintf(os.Stderr, "email %s did not match any users\n", email)
-				os.Exit(1)
+				return fmt.Errorf("email %s did not match any users", email)
 			}
 
 			addresses, err := client.GetAddressesByUser(ctx, userMap[email])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%s\t%v\t%s\t%v\t%s\t%s\t%v\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Address ID", "Contact Name",
-				"Address 1", "Address 2", "City", "County",
-				"Postcode", "Country Code", "Created", "Modified")
-			fmt.Fprintf(tw, format, "----------", "------------",
-				"---------", "---------", "----", "------",
-				"--------", "------------", "-------", "--------")
+			t := output.NewTable("Address ID", "Contact Name", "Address 1",
+				"Address 2", "City", "County", "Postcode", "Country Code",
+				"Created", "Modified").Wide("Type")
 			for _, a := range addresses {
-				addr2 := "-"
-				if a.Addr2 != nil {
-					addr2 = *a.Addr2
-				}
-				county := "-"
-				if a.County != nil {
-					county = *a.County
-				}
-				fmt.Fprintf(tw, format, a.ID,
-					a.ContactName, a.Addr1, addr2,
-					a.City, county, a.Postcode,
-					a.CountryCode,
-					a.Created.In(location).Format(timeDisplayFormat),
-					a.Modified.In(location).Format(timeDisplayFormat))
+				t.Row(a.ID, a.ContactName, a.Addr1, a.Addr2, a.City, a.County,
+					a.Postcode, a.CountryCode, a.Created, a.Modified, a.Typ)
 			}
-			fmt.Fprintf(tw, "(%d addresses found)\n", len(addresses))
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, addresses, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
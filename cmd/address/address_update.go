@@ -1,12 +1,11 @@
 package address
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/spf13/cobra"
@@ -14,46 +13,35 @@ import (
 )
 
 // NewCmdAddressUpdate returns new initialized instance of update sub command
-func NewCmdAddressUpdate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdAddressUpdate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "update <address_id>",
 		Short: "Update an address",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// address id
 			addrID := args[0]
 			if !cmdvalidate.IsValidUUID(addrID) {
-				fmt.Fprintf(os.Stderr, "address_id value %q is not a valid v4 uuid\n", addrID)
-				os.Exit(1)
+				return fmt.Errorf("address_id value %q is not a valid v4 uuid", addrID)
 			}
 
-			ctx := context.Background()
 			addr, err := client.GetAddress(ctx, addrID)
-			if err == eclient.ErrAddressNotFound {
-				fmt.Fprintf(os.Stderr, "address id %s not found\n", addrID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrAddressNotFound) {
+				return fmt.Errorf("address id %s not found", addrID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			req, err := promptUpdateAddress(addr)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			if req.Type == nil && req.ContactName == nil &&
@@ -61,15 +49,15 @@ func NewCmdAddressUpdate() *cobra.Command {
 				req.City == nil && req.County == nil &&
 				req.Postcode == nil &&
 				req.CountryCode == nil {
-				os.Exit(0)
+				return nil
 			}
 
 			updated, err := client.UpdateAddress(ctx, addrID, req)
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				fmt.Fprintf(os.Stderr, "error updating address: %+v", err)
+				return fmt.Errorf("error updating address: %w", err)
 			}
-			showAddress(updated)
+			showAddress(f.IOStreams.Out, updated)
+			return nil
 		},
 	}
 	return cmd
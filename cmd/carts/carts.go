@@ -1,20 +1,21 @@
 package carts
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCarts returns new initialized instance of carts sub command
-func NewCmdCarts() *cobra.Command {
+func NewCmdCarts(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "carts",
 		Short: "Carts management",
 	}
-	cmd.AddCommand(NewCmdCartsCreate())
-	cmd.AddCommand(NewCmdCartListProducts())
-	cmd.AddCommand(NewCmdCartsAddProduct())
-	cmd.AddCommand(NewCmdCartUpdateProduct())
-	cmd.AddCommand(NewCmdCartDeleteProduct())
-	cmd.AddCommand(NewCmdCartEmptyProducts())
+	cmd.AddCommand(NewCmdCartsCreate(f))
+	cmd.AddCommand(NewCmdCartListProducts(f))
+	cmd.AddCommand(NewCmdCartsAddProduct(f))
+	cmd.AddCommand(NewCmdCartUpdateProduct(f))
+	cmd.AddCommand(NewCmdCartDeleteProduct(f))
+	cmd.AddCommand(NewCmdCartEmptyProducts(f))
 	return cmd
 }
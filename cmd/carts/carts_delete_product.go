@@ -1,52 +1,47 @@
 package carts
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCartDeleteProduct returns new initialized instance of the delete-product sub command
-func NewCmdCartDeleteProduct() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCartDeleteProduct(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete-product <cart_product_id>",
 		Short: "Remove a product from a cart",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			cartProductID := args[0]
 			if !cmdvalidate.IsValidUUID(cartProductID) {
-				fmt.Fprintf(os.Stderr, "cart_product_id value (%q) is not a valid v4 uuid\n", cartProductID)
-				os.Exit(1)
+				return fmt.Errorf("cart_product_id value (%q) is not a valid v4 uuid", cartProductID)
 			}
 
-			ctx := context.Background()
+			if err := f.Confirm(yes, "remove cart product %s", cartProductID); err != nil {
+				return err
+			}
 			err = client.CartsRemoveProduct(ctx, cartProductID)
-			if err == eclient.ErrCartProductNotFound {
-				fmt.Fprintf(os.Stderr, "cart product %q not found\n", cartProductID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrCartProductNotFound) {
+				return fmt.Errorf("cart product %q not found", cartProductID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
@@ -1,52 +1,45 @@
 package carts
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCartEmptyProducts returns new initialized instance of the empty sub command.
-func NewCmdCartEmptyProducts() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCartEmptyProducts(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "empty-products",
 		Short: "empty all products from the cart",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			cartID := os.Getenv("ECOM_CLI_CART_ID")
-			if !cmdvalidate.IsValidUUID(cartID) {
-				fmt.Fprintf(os.Stderr, "ECOM_CLI_CART_ID value (%q) is not a valid v4 uuid\n", cartID)
-				os.Exit(1)
+			cartID, err := f.CartID()
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
-			err := client.EmptyCartProducts(ctx, cartID)
-			if err == eclient.ErrCartNotFound {
-				fmt.Fprintf(os.Stderr, "cart %q not found. Set the environment variable ECOM_CLI_CART_ID to a valid v4 uuid.\n", cartID)
-				os.Exit(1)
+			if err := f.Confirm(yes, "remove every product from cart %s", cartID); err != nil {
+				return err
+			}
+			err = client.EmptyCartProducts(ctx, cartID)
+			if errors.Is(err, eclient.ErrCartNotFound) {
+				return fmt.Errorf("cart %q not found. Set the environment variable ECOM_CLI_CART_ID or the cart preference to a valid v4 uuid.", cartID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
-
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
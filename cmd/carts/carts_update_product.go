@@ -1,26 +1,20 @@
 package carts
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
 	"text/tabwriter"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCartUpdateProduct returns new initialized instance of the update-product sub command
-func NewCmdCartUpdateProduct() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCartUpdateProduct(f *cmdutil.Factory) *cobra.Command {
 	var cartProductID string
 	var qty int
 	var cmd = &cobra.Command{
@@ -36,6 +30,7 @@ func NewCmdCartUpdateProduct() *cobra.Command {
 				return fmt.Errorf("cart_product_id value %q is not a valid v4 uuid", cartProductID)
 			}
 
+			var err error
 			qty, err = strconv.Atoi(args[1])
 			if err != nil {
 				return fmt.Errorf("<qty> must be an integer value: %v", err)
@@ -44,38 +39,35 @@ func NewCmdCartUpdateProduct() *cobra.Command {
 			return nil
 		},
 		// cobra.ExactArgs(2),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
 			cartProduct, err := client.UpdateCartProduct(ctx, cartProductID, qty)
-			if err == eclient.ErrCartProductNotFound {
-				fmt.Fprintf(os.Stderr, "cart product %q not found\n", cartProductID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrCartProductNotFound) {
+				return fmt.Errorf("cart product %q not found", cartProductID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 			fmt.Fprintf(tw, format, "Product cart ID:", cartProduct.ID)
 			fmt.Fprintf(tw, format, "SKU:", cartProduct.SKU)
 			fmt.Fprintf(tw, format, "Product ID", cartProduct.ProductID)
 			fmt.Fprintf(tw, format, "Name:", cartProduct.Name)
 			fmt.Fprintf(tw, format, "Qty:", cartProduct.Qty)
-			fmt.Fprintf(tw, format, "Unit price:", cartProduct.UnitPrice)
+			fmt.Fprintf(tw, format, "Unit price:", display.Price(cartProduct.UnitPrice, ""))
 			fmt.Fprintf(tw, format, "Created:",
-				cartProduct.Created.In(location).Format(timeDisplayFormat))
+				display.Time(cartProduct.Created))
 			fmt.Fprintf(tw, format, "Modified:",
-				cartProduct.Modified.In(location).Format(timeDisplayFormat))
+				display.Time(cartProduct.Modified))
 			tw.Flush()
+			return nil
 		},
 	}
 	return cmd
@@ -1,17 +1,18 @@
 package categoriestree
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCategoriesTree returns new initialized instance of the catalog sub command
-func NewCmdCategoriesTree() *cobra.Command {
+func NewCmdCategoriesTree(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "categories-tree",
 		Short: "Categories Tree management",
 	}
-	cmd.AddCommand(NewCmdCategoriesTreeApply())
-	cmd.AddCommand(NewCmdCategoriesTreeGet())
-	cmd.AddCommand(NewCmdCategoriesTreeDelete())
+	cmd.AddCommand(NewCmdCategoriesTreeApply(f))
+	cmd.AddCommand(NewCmdCategoriesTreeGet(f))
+	cmd.AddCommand(NewCmdCategoriesTreeDelete(f))
 	return cmd
 }
@@ -4,68 +4,70 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/url"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 )
 
 // NewCmdCategoriesTreeApply returns new initialized instance of apply sub command
-func NewCmdCategoriesTreeApply() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdCategoriesTreeApply(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "apply <catalog.yaml>",
 		Short: "Replace the categories tree",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			if err := cmdvalidate.Catalog(args[0], data); err != nil {
+				return err
 			}
 
-			data, err := ioutil.ReadFile(args[0])
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			var catalog eclient.CatalogYAML
 			if err = yaml.Unmarshal([]byte(data), &catalog); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+
+			current, err := f.Profile()
+			if err != nil {
+				return err
 			}
 
 			// disallow applying the catalog.yaml files with endpoints: ['host1', 'host2']
 			// guards to the system.
 			ok, err := isValidEndpoint(current.Endpoint, catalog.Endpoints)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			if !ok {
-				fmt.Fprintf(os.Stderr, "The catalog.yaml file has endpoint guards for %v only. Your current profile endpoint is %q. Either switch profiles using 'ecom profiles select' or adjust the catalog.yaml file.\n", catalog.Endpoints, current.Endpoint)
-				os.Exit(1)
+				return fmt.Errorf("the catalog.yaml file has endpoint guards for %v only. Your current profile endpoint is %q. Either switch profiles using 'ecom profiles select' or adjust the catalog.yaml file.", catalog.Endpoints, current.Endpoint)
 			}
 
 			// build a request
 			root := catalog.Category
 			catRequest := buildRequest(&root)
 
-			if err := client.UpdateCategoriesTree(catRequest); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v", err)
-				os.Exit(1)
+			if err := f.Confirm(yes, "replace the categories tree with the tree in %s", args[0]); err != nil {
+				return err
+			}
+			if err := client.UpdateCategoriesTree(ctx, catRequest); err != nil {
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
 
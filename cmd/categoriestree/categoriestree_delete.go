@@ -1,37 +1,35 @@
 package categoriestree
 
 import (
-	"fmt"
-	"os"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCategoriesTreeDelete returns new initialized instance of the purge sub command
-func NewCmdCategoriesTreeDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCategoriesTreeDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete",
 		Short: "Delete the categories tree",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+			categories, err := client.GetCategories(ctx)
+			if err != nil {
+				return err
+			}
+			if err := f.Confirm(yes, "purge the entire catalog of %d categories and their product relations", len(categories)); err != nil {
+				return err
 			}
-			if err := client.PurgeCatalog(); err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+			if err := client.PurgeCatalog(ctx); err != nil {
+				return err
 			}
-			os.Exit(0)
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
@@ -2,44 +2,43 @@ package categoriestree
 
 import (
 	"fmt"
-	"os"
+	"io"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCategoriesTreeGet returns new initialized instance of list get command
-func NewCmdCategoriesTreeGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdCategoriesTreeGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get",
 		Short: "Get the categories tree",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
-			root, err := client.GetCategoriesTree()
+			root, err := client.GetCategoriesTree(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			treeView(root, 0, false)
+			if out.Human() {
+				treeView(f.IOStreams.Out, root, 0, false)
+				return nil
+			}
+			return out.Print(f.IOStreams.Out, root, nil)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
 
-func treeView(node *eclient.CategoryTreeResponse, depth int, lastSibling bool) {
+func treeView(w io.Writer, node *eclient.CategoryTreeResponse, depth int, lastSibling bool) {
 	// fmt.Printf("%+v\n", node)
 	// fmt.Printf("node.Name=%s last sibling=%t\n", node.Name, lastSibling)
 	var arm string
@@ -51,17 +50,17 @@ func treeView(node *eclient.CategoryTreeResponse, depth int, lastSibling bool) {
 
 	if depth == 0 {
 	} else if depth == 1 {
-		fmt.Print(arm)
+		fmt.Fprint(w, arm)
 	} else {
-		fmt.Print("│   ")
+		fmt.Fprint(w, "│   ")
 		for i := 0; i < depth-2; i++ {
-			fmt.Print("    ")
+			fmt.Fprint(w, "    ")
 		}
-		fmt.Print(arm)
+		fmt.Fprint(w, arm)
 	}
-	fmt.Printf("%s (%s)\n", node.Segment, node.Name)
+	fmt.Fprintf(w, "%s (%s)\n", node.Segment, node.Name)
 	lastIdx := len(node.Categories.Data) - 1
 	for i, n := range node.Categories.Data {
-		treeView(n, depth+1, lastIdx == i)
+		treeView(w, n, depth+1, lastIdx == i)
 	}
 }
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
+	"time"
+
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/address"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/carts"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/categoriestree"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/coupons"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/devkeys"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/inventory"
@@ -21,6 +25,7 @@ import (
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/token"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/users"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd/webhooks"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 
 	"github.com/spf13/cobra"
 )
@@ -28,35 +33,66 @@ import (
 // var rc *configmgr.EcomConfigurations
 // var currentConfigName string
 
-// NewEcomCmd creates the `ecom` command.
-func NewEcomCmd() *cobra.Command {
+// NewEcomCmd creates the `ecom` command. Sub commands get their
+// configuration and API client from f when they run.
+func NewEcomCmd(f *cmdutil.Factory) *cobra.Command {
+	var timeout time.Duration
+	var verbose, debugHTTP bool
+	var profile, endpoint string
 	var cmd = &cobra.Command{
 		Use:   "ecom",
 		Short: "ecom is a CLI tool for administering ecommerce systems",
 		Long:  `See the user guide for more details.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if profile != "" && endpoint != "" {
+				return errors.New("--profile and --endpoint cannot be used together")
+			}
+			// the arguments are valid, so from here on errors come from
+			// running the command and the usage is not worth repeating
+			cmd.SilenceUsage = true
+
+			configmgr.SetOverride(profile, endpoint)
+			cmdutil.InitContext(timeout)
+			cmdutil.SetVerbose(verbose)
+			cmdutil.SetHTTPDebug(debugHTTP)
+			return nil
+		},
+		SilenceErrors: true,
 	}
-	cmd.AddCommand(address.NewCmdAddress())
-	cmd.AddCommand(carts.NewCmdCarts())
-	cmd.AddCommand(coupons.NewCmdCoupons())
-	cmd.AddCommand(categoriestree.NewCmdCategoriesTree())
-	cmd.AddCommand(devkeys.NewCmdDevKeys())
-	cmd.AddCommand(inventory.NewCmdInventory())
-	cmd.AddCommand(offers.NewCmdOffers())
-	cmd.AddCommand(orders.NewCmdOrders())
-	cmd.AddCommand(pcrelations.NewCmdPCRelations())
-	cmd.AddCommand(products.NewCmdProducts())
-	cmd.AddCommand(ppassocs.NewCmdPPAssocs())
-	cmd.AddCommand(ppagroups.NewCmdPPAGroups())
-	cmd.AddCommand(prices.NewCmdPrices())
-	cmd.AddCommand(pricelists.NewCmdPriceLists())
-	cmd.AddCommand(profiles.NewCmdProfiles())
-	cmd.AddCommand(promorules.NewCmdPromoRules())
-	cmd.AddCommand(tariffs.NewCmdShippingTariffsRules())
-	cmd.AddCommand(users.NewCmdUsers())
-	cmd.AddCommand(webhooks.NewCmdWebhooks())
-	cmd.AddCommand(NewCmdCompletion())
-	cmd.AddCommand(NewCmdSysInfo())
-	cmd.AddCommand(token.NewCmdToken())
-	cmd.AddCommand(NewCmdVersion())
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 0,
+		"maximum time to wait for the command to complete, e.g. 30s or 5m (0 means no limit)")
+	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false,
+		"print diagnostic messages, such as retried requests, to stderr")
+	cmd.PersistentFlags().BoolVar(&debugHTTP, "debug-http", cmdutil.DebugFromEnv(),
+		"trace HTTP requests and responses to stderr with secrets redacted (default from ECOM_DEBUG)")
+	cmd.PersistentFlags().StringVar(&profile, "profile", "",
+		"use the named profile for this command only (default from ECOM_PROFILE, then profiles select)")
+	cmd.PersistentFlags().StringVar(&endpoint, "endpoint", "",
+		"use the profile with this endpoint for this command only")
+	cmd.AddCommand(address.NewCmdAddress(f))
+	cmd.AddCommand(carts.NewCmdCarts(f))
+	cmd.AddCommand(coupons.NewCmdCoupons(f))
+	cmd.AddCommand(categoriestree.NewCmdCategoriesTree(f))
+	cmd.AddCommand(devkeys.NewCmdDevKeys(f))
+	cmd.AddCommand(inventory.NewCmdInventory(f))
+	cmd.AddCommand(offers.NewCmdOffers(f))
+	cmd.AddCommand(orders.NewCmdOrders(f))
+	cmd.AddCommand(pcrelations.NewCmdPCRelations(f))
+	cmd.AddCommand(products.NewCmdProducts(f))
+	cmd.AddCommand(ppassocs.NewCmdPPAssocs(f))
+	cmd.AddCommand(ppagroups.NewCmdPPAGroups(f))
+	cmd.AddCommand(prices.NewCmdPrices(f))
+	cmd.AddCommand(pricelists.NewCmdPriceLists(f))
+	cmd.AddCommand(profiles.NewCmdProfiles(f))
+	cmd.AddCommand(promorules.NewCmdPromoRules(f))
+	cmd.AddCommand(tariffs.NewCmdShippingTariffsRules(f))
+	cmd.AddCommand(users.NewCmdUsers(f))
+	cmd.AddCommand(webhooks.NewCmdWebhooks(f))
+	cmd.AddCommand(NewCmdCompletion(f))
+	cmd.AddCommand(NewCmdLogin(f))
+	cmd.AddCommand(NewCmdSysInfo(f))
+	cmd.AddCommand(token.NewCmdToken(f))
+	cmd.AddCommand(NewCmdValidate(f))
+	cmd.AddCommand(NewCmdVersion(f))
 	return cmd
 }
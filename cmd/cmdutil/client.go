@@ -0,0 +1,75 @@
+package cmdutil
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+var verbose, debugHTTP bool
+
+// SetVerbose enables diagnostic output, such as retried requests, on
+// stderr for clients returned by NewClient.
+func SetVerbose(v bool) {
+	verbose = v
+}
+
+// SetHTTPDebug enables tracing of every HTTP request and response on
+// stderr for clients returned by NewClient.
+func SetHTTPDebug(v bool) {
+	debugHTTP = v
+}
+
+// DebugFromEnv reports whether the ECOM_DEBUG environment variable
+// requests HTTP tracing. Any value other than a false boolean enables it.
+func DebugFromEnv() bool {
+	v := os.Getenv("ECOM_DEBUG")
+	if v == "" {
+		return false
+	}
+	if b, err := strconv.ParseBool(v); err == nil {
+		return b
+	}
+	return true
+}
+
+// NewClient returns an EcomClient for the profile cfg configured
+// according to the global command line flags and environment.
+func NewClient(cfg *configmgr.EcomConfigEntry) (*eclient.EcomClient, error) {
+	secureToken, identityToolkit := identityURLs(cfg)
+	client, err := eclient.New(cfg.Endpoint,
+		eclient.WithSecureTokenURL(secureToken),
+		eclient.WithIdentityToolkitURL(identityToolkit))
+	if err != nil {
+		return nil, err
+	}
+	if verbose {
+		client.SetLogger(log.New(os.Stderr, "ecom: ", 0))
+	}
+	if debugHTTP {
+		client.SetHTTPDebug(os.Stderr)
+	}
+	return client, nil
+}
+
+// identityURLs returns the base URLs of the identity services for cfg.
+// ECOM_SECURETOKEN_URL and ECOM_IDENTITYTOOLKIT_URL take precedence,
+// followed by FIREBASE_AUTH_EMULATOR_HOST and then the profile. Empty
+// results leave the eclient defaults in place.
+func identityURLs(cfg *configmgr.EcomConfigEntry) (secureToken, identityToolkit string) {
+	secureToken, identityToolkit = cfg.SecureTokenURL, cfg.IdentityToolkitURL
+	if host := os.Getenv("FIREBASE_AUTH_EMULATOR_HOST"); host != "" {
+		secureToken = "http://" + host + "/securetoken.googleapis.com"
+		identityToolkit = "http://" + host + "/www.googleapis.com"
+	}
+	if v := os.Getenv("ECOM_SECURETOKEN_URL"); v != "" {
+		secureToken = v
+	}
+	if v := os.Getenv("ECOM_IDENTITYTOOLKIT_URL"); v != "" {
+		identityToolkit = v
+	}
+	return secureToken, identityToolkit
+}
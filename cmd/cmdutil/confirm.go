@@ -0,0 +1,98 @@
+package cmdutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// AddYesFlag adds the --yes (-y) flag to a destructive command, skipping
+// the confirmation asked for by Confirm and Ask.
+func AddYesFlag(cmd *cobra.Command, yes *bool) {
+	cmd.Flags().BoolVarP(yes, "yes", "y", false,
+		"do not ask for confirmation")
+}
+
+// Ask guards a command destructive enough to confirm whatever the
+// profile, such as products apply --prune. It asks question, for example
+// "Delete 3 products?", and returns nil only if the answer is yes. When
+// stdin is not a terminal nothing is asked and --yes is required. If yes
+// is set, from --yes, it returns nil straight away.
+func (f *Factory) Ask(yes bool, question string) error {
+	if yes {
+		return nil
+	}
+	in, ok := terminal(f.IOStreams.In)
+	if !ok {
+		return fmt.Errorf("stdin is not a terminal; use --yes to confirm")
+	}
+	fmt.Fprintf(f.IOStreams.ErrOut, "%s [y/N] ", question)
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Fprintln(f.IOStreams.ErrOut)
+	}
+	switch strings.ToLower(strings.TrimSpace(line)) {
+	case "y", "yes":
+		return nil
+	}
+	return fmt.Errorf("not confirmed; nothing was changed")
+}
+
+// Confirm guards a destructive command. If the selected profile is
+// protected, see configmgr.EcomConfigEntry.Protected, it describes what
+// the command will do, formatted from format and args, and asks for the
+// profile name to be typed. Without a matching answer it returns an
+// error and the command must stop. Nothing is asked when stdin is not a
+// terminal or the session uses an ephemeral profile, see
+// configmgr.EphemeralProfile; --yes is then required. If yes is set, from
+// --yes, it returns nil straight away.
+func (f *Factory) Confirm(yes bool, format string, args ...interface{}) error {
+	if yes {
+		return nil
+	}
+	if _, ok := configmgr.EphemeralProfile(); ok {
+		return fmt.Errorf("this will %s; use --yes to confirm with the %s and %s profile",
+			fmt.Sprintf(format, args...), configmgr.EndpointEnv, configmgr.DevKeyEnv)
+	}
+	cfgs, name, err := f.Config()
+	if err != nil {
+		return err
+	}
+	e, ok := cfgs.Configurations[name]
+	if !ok || !e.Protected() {
+		return nil
+	}
+	in, ok := terminal(f.IOStreams.In)
+	if !ok {
+		return fmt.Errorf("profile %q is protected and stdin is not a terminal; use --yes to confirm", name)
+	}
+
+	w := f.IOStreams.ErrOut
+	fmt.Fprintf(w, "Profile %q (%s) is a %s profile.\n", name, e.Endpoint, e.Environment)
+	fmt.Fprintf(w, "This will %s.\n", fmt.Sprintf(format, args...))
+	fmt.Fprintf(w, "Type the profile name to continue: ")
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		fmt.Fprintln(w)
+		return fmt.Errorf("profile %q is protected; confirm by typing its name or use --yes", name)
+	}
+	if strings.TrimSpace(line) != name {
+		return fmt.Errorf("%q does not match the profile name; nothing was changed", strings.TrimSpace(line))
+	}
+	return nil
+}
+
+// terminal returns r as an *os.File if it is a terminal.
+func terminal(r io.Reader) (*os.File, bool) {
+	in, ok := r.(*os.File)
+	if !ok || !(isatty.IsTerminal(in.Fd()) || isatty.IsCygwinTerminal(in.Fd())) {
+		return nil, false
+	}
+	return in, true
+}
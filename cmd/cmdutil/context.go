@@ -0,0 +1,39 @@
+package cmdutil
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+)
+
+var ctx = context.Background()
+
+// Context returns the context for the running command. It is cancelled
+// on the first interrupt signal or once the --timeout deadline passes.
+func Context() context.Context {
+	return ctx
+}
+
+// InitContext sets up the command context with an optional deadline
+// (zero means no deadline) and cancellation on SIGINT. A second SIGINT
+// exits immediately without waiting for in-flight work to wind down.
+func InitContext(timeout time.Duration) {
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt)
+	go func() {
+		<-sigs
+		fmt.Fprintf(os.Stderr, "Interrupted. Finishing the current operation (press Ctrl-C again to force quit).\n")
+		cancel()
+		<-sigs
+		os.Exit(130)
+	}()
+}
@@ -0,0 +1,41 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
+)
+
+// CartEnv names the environment variable holding the cart the carts
+// commands work on. It takes precedence over the cart preference of the
+// profile.
+const CartEnv = "ECOM_CLI_CART_ID"
+
+// CartID returns the cart to work on: the value of ECOM_CLI_CART_ID if
+// set, otherwise the cart preference of the selected profile.
+func (f *Factory) CartID() (string, error) {
+	cartID, from := os.Getenv(CartEnv), CartEnv
+	if cartID == "" {
+		if p, err := f.Profile(); err == nil {
+			cartID, from = p.Preferences.Cart, "preference cart"
+		}
+	}
+	if cartID == "" {
+		return "", fmt.Errorf("no cart; set %s or use ecom profiles set <name> cart <cart_id>", CartEnv)
+	}
+	if !cmdvalidate.IsValidUUID(cartID) {
+		return "", fmt.Errorf("%s value (%q) is not a valid v4 uuid", from, cartID)
+	}
+	return cartID, nil
+}
+
+// PriceListCode returns the price list preference of the selected
+// profile, or an empty string if it has none.
+func (f *Factory) PriceListCode() string {
+	p, err := f.Profile()
+	if err != nil {
+		return ""
+	}
+	return p.Preferences.PriceList
+}
@@ -0,0 +1,126 @@
+package cmdutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+// IOStreams are the input and output streams of a command.
+type IOStreams struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+}
+
+// Factory gives commands access to the configuration, the API client
+// and the IO streams. Commands receive it when the command tree is built
+// and call Config and Client from their RunE functions, so nothing is
+// loaded until a command runs. Tests can replace any of the fields.
+type Factory struct {
+	IOStreams IOStreams
+
+	// Config returns the profiles and the name of the selected profile.
+	Config func() (*configmgr.EcomConfigurations, string, error)
+
+	// Client returns an API client for the selected profile, signed in
+	// with the profile's token.
+	Client func(ctx context.Context) (*eclient.EcomClient, error)
+}
+
+// NewFactory returns a Factory using the standard streams and the
+// configuration in the user's home directory. The configuration is read
+// once, on first use, when the selected profile's preferences are
+// applied with display.Set, so the --profile and --endpoint flags must be
+// applied with configmgr.SetOverride before then. If
+// configmgr.EphemeralProfile reports a profile, Client signs in with its
+// developer key instead and nothing is read from the home directory.
+func NewFactory() *Factory {
+	f := &Factory{
+		IOStreams: IOStreams{
+			In:     os.Stdin,
+			Out:    os.Stdout,
+			ErrOut: os.Stderr,
+		},
+	}
+
+	var cfgs *configmgr.EcomConfigurations
+	var curCfg string
+	var cfgErr error
+	var loaded bool
+	f.Config = func() (*configmgr.EcomConfigurations, string, error) {
+		if !loaded {
+			cfgs, curCfg, cfgErr = configmgr.GetCurrentConfig()
+			loaded = true
+			if cfgErr == nil {
+				if e, ok := cfgs.Configurations[curCfg]; ok {
+					if err := display.Set(e.Preferences); err != nil {
+						cfgErr = fmt.Errorf("profile %q preferences: %w", curCfg, err)
+					}
+				}
+			}
+		}
+		return cfgs, curCfg, cfgErr
+	}
+
+	var client *eclient.EcomClient
+	f.Client = func(ctx context.Context) (*eclient.EcomClient, error) {
+		if client != nil {
+			return client, nil
+		}
+		if e, ok := configmgr.EphemeralProfile(); ok {
+			c, err := NewClient(e)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := c.SignInEphemeral(ctx, e.DevKey); err != nil {
+				return nil, fmt.Errorf("sign in with %s failed: %w", configmgr.DevKeyEnv, err)
+			}
+			client = c
+			return client, nil
+		}
+		current, err := f.Profile()
+		if err != nil {
+			return nil, err
+		}
+		c, err := NewClient(current)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.SetToken(ctx, current); err != nil {
+			// a profile imported without its token signs in again
+			if !errors.Is(err, configmgr.ErrCredentialNotFound) || current.DevKey == "" {
+				return nil, err
+			}
+			if _, err := c.SignIn(ctx, current); err != nil {
+				return nil, fmt.Errorf("sign in with developer key failed: %w", err)
+			}
+		}
+		client = c
+		return client, nil
+	}
+	return f
+}
+
+// Profile returns the selected profile, or the ephemeral profile if one
+// is set.
+func (f *Factory) Profile() (*configmgr.EcomConfigEntry, error) {
+	if e, ok := configmgr.EphemeralProfile(); ok {
+		return e, nil
+	}
+	cfgs, curCfg, err := f.Config()
+	if err != nil {
+		return nil, err
+	}
+	current, ok := cfgs.Configurations[curCfg]
+	if !ok {
+		return nil, fmt.Errorf("no profile selected; use ecom profiles create or ecom profiles select")
+	}
+	return &current, nil
+}
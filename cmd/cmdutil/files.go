@@ -0,0 +1,20 @@
+package cmdutil
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// YAMLFiles returns the .yaml and .yml files in dir, sorted by name.
+func YAMLFiles(dir string) ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"*.yaml", "*.yml"} {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
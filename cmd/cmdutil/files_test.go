@@ -0,0 +1,41 @@
+package cmdutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestYAMLFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "yamlfiles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	for _, name := range []string{"b.yml", "a.yaml", "c.yaml", "notes.txt", "d.YAML.bak"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "sub", "e.yaml"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := YAMLFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "b.yml"),
+		filepath.Join(dir, "c.yaml"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("YAMLFiles = %v, want %v", got, want)
+	}
+}
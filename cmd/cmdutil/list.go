@@ -0,0 +1,15 @@
+package cmdutil
+
+import (
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/spf13/cobra"
+)
+
+// AddListFlags adds the --limit and --page-size flags used by list
+// commands to page through results.
+func AddListFlags(cmd *cobra.Command, opts *eclient.ListOptions) {
+	cmd.Flags().IntVar(&opts.Limit, "limit", 0,
+		"maximum number of items to list (0 means all)")
+	cmd.Flags().IntVar(&opts.PageSize, "page-size", 0,
+		"number of items to fetch per request (0 uses the API default)")
+}
@@ -0,0 +1,30 @@
+package cmdutil
+
+import (
+	"fmt"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+)
+
+// CheckNewProfile returns an error if saving entry as the profile name
+// would replace an existing profile, or the credentials of another
+// profile, and overwrite is false.
+func CheckNewProfile(cfgs *configmgr.EcomConfigurations, name string, entry *configmgr.EcomConfigEntry, overwrite bool) error {
+	if overwrite {
+		return nil
+	}
+	if _, ok := cfgs.Configurations[name]; ok {
+		return fmt.Errorf("profile %q already exists; use --overwrite to replace it", name)
+	}
+	filename, err := configmgr.TokenName(entry)
+	if err != nil {
+		return err
+	}
+	for other, e := range cfgs.Configurations {
+		e := e
+		if fn, err := configmgr.TokenName(&e); err == nil && fn == filename {
+			return fmt.Errorf("profile %q already uses the credentials %s; use --overwrite to replace them", other, filename)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-isatty"
+)
+
+// Progress draws a progress bar for long running commands. It only
+// draws when writing to a terminal, so redirected output is left
+// clean. It is safe for concurrent use.
+type Progress struct {
+	w     io.Writer
+	label string
+	total int
+
+	mu   sync.Mutex
+	done int
+}
+
+// NewProgress returns a Progress counting up to total. Nothing is drawn
+// unless w is a terminal.
+func NewProgress(w io.Writer, label string, total int) *Progress {
+	f, ok := w.(*os.File)
+	if !ok || !(isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())) {
+		w = nil
+	}
+	p := Progress{w: w, label: label, total: total}
+	p.draw()
+	return &p
+}
+
+// Add records n more items done.
+func (p *Progress) Add(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.done += n
+	p.draw()
+}
+
+// Finish clears the progress bar.
+func (p *Progress) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.w != nil {
+		fmt.Fprintf(p.w, "\r\033[K")
+	}
+}
+
+const progressWidth = 30
+
+func (p *Progress) draw() {
+	if p.w == nil || p.total == 0 {
+		return
+	}
+	n := progressWidth * p.done / p.total
+	bar := strings.Repeat("=", n)
+	if n < progressWidth {
+		bar += ">" + strings.Repeat(" ", progressWidth-n-1)
+	}
+	fmt.Fprintf(p.w, "\r%s [%s] %d/%d", p.label, bar, p.done, p.total)
+}
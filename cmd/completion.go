@@ -15,13 +15,12 @@
 package cmd
 
 import (
-	"os"
-
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCompletion returns new initialized instance of the completion sub command
-func NewCmdCompletion() *cobra.Command {
+func NewCmdCompletion(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "completion",
 		Short: "Generates bash completion scripts",
@@ -33,8 +32,8 @@ To configure your bash shell to load completions for each session add to your ba
 # ~/.bashrc or ~/.profile
 . <(bitbucket completion)
 `,
-		Run: func(cmd *cobra.Command, args []string) {
-			cmd.GenBashCompletion(os.Stdout)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.GenBashCompletion(f.IOStreams.Out)
 		},
 	}
 	return cmd
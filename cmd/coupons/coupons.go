@@ -1,19 +1,20 @@
 package coupons
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCoupons returns new initialized instance of coupons sub command
-func NewCmdCoupons() *cobra.Command {
+func NewCmdCoupons(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "coupons",
 		Short: "Coupons management",
 	}
-	cmd.AddCommand(NewCmdCouponsCreate())
-	cmd.AddCommand(NewCmdCouponsGet())
-	cmd.AddCommand(NewCmdCouponsList())
-	cmd.AddCommand(NewCmdCouponsDelete())
-	cmd.AddCommand(NewCmdCouponsVoid())
+	cmd.AddCommand(NewCmdCouponsCreate(f))
+	cmd.AddCommand(NewCmdCouponsGet(f))
+	cmd.AddCommand(NewCmdCouponsList(f))
+	cmd.AddCommand(NewCmdCouponsDelete(f))
+	cmd.AddCommand(NewCmdCouponsVoid(f))
 	return cmd
 }
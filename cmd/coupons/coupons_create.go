@@ -2,10 +2,8 @@ package coupons
 
 import (
 	"context"
-	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -13,37 +11,29 @@ import (
 )
 
 // NewCmdCouponsCreate returns new initialized instance of create sub command
-func NewCmdCouponsCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCouponsCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Mints a new coupon",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// get the request params
-			ctx := context.Background()
 			req, err := promptCreateCoupon(ctx, client)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			coupon, err := client.CreateCoupon(ctx, req)
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				os.Exit(1)
+				return err
 			}
-			showCoupon(coupon)
+			showCoupon(f.IOStreams.Out, coupon)
+			return nil
 		},
 	}
 	return cmd
@@ -1,41 +1,33 @@
 package coupons
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCouponsDelete returns new initialized instance of the delete sub command
-func NewCmdCouponsDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCouponsDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <coupon_code>",
 		Short: "Delete a coupon",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// coupon_code to id
 			couponCode := args[0]
-			ctx := context.Background()
 			coupons, err := client.GetCoupons(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var coupon *eclient.Coupon
 			for _, c := range coupons {
@@ -45,20 +37,22 @@ func NewCmdCouponsDelete() *cobra.Command {
 				}
 			}
 			if coupon == nil {
-				fmt.Fprintf(os.Stderr, "coupon_code %q not found\n", couponCode)
-				os.Exit(1)
+				return fmt.Errorf("coupon_code %q not found", couponCode)
 			}
 
+			if err := f.Confirm(yes, "delete coupon %q (%s)", couponCode, coupon.ID); err != nil {
+				return err
+			}
 			err = client.DeleteCoupon(ctx, coupon.ID)
-			if err == eclient.ErrCouponNotFound {
-				fmt.Fprintf(os.Stderr, "coupon not found. Use ecom coupons list to check.\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrCouponNotFound) {
+				return fmt.Errorf("coupon not found. Use ecom coupons list to check.")
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
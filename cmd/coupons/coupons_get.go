@@ -1,42 +1,34 @@
 package coupons
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"io"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdCouponsGet returns new initialized instance of the get sub command
-func NewCmdCouponsGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdCouponsGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get <coupon_code>",
 		Short: "Get coupon code",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// coupon_code to id
 			couponCode := args[0]
-			ctx := context.Background()
 			coupons, err := client.GetCoupons(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var coupon *eclient.Coupon
 			for _, c := range coupons {
@@ -46,28 +38,25 @@ func NewCmdCouponsGet() *cobra.Command {
 				}
 			}
 			if coupon == nil {
-				fmt.Fprintf(os.Stderr, "coupon_code %q not found\n", couponCode)
-				os.Exit(1)
+				return fmt.Errorf("coupon_code %q not found", couponCode)
 			}
 
-			showCoupon(coupon)
+			return out.Print(f.IOStreams.Out, coupon, couponRecord(coupon))
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
 
-func showCoupon(v *eclient.Coupon) {
-	format := "%v\t%v\t\n"
-	tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, format, "Coupon ID:", v.ID)
-	fmt.Fprintf(tw, format, "Promo Rule ID:", v.PromoRuleID)
-	fmt.Fprintf(tw, format, "Promo Rule Code:", v.PromoRuleCode)
-	fmt.Fprintf(tw, format, "Resuable:", v.Resuable)
-	fmt.Fprintf(tw, format, "Void:", v.Void)
-	fmt.Fprintf(tw, format, "Spent Count:", v.SpendCount)
-	fmt.Fprintf(tw, format, "Created:",
-		v.Created.In(location).Format(timeDisplayFormat))
-	fmt.Fprintf(tw, format, "Modified:",
-		v.Modified.In(location).Format(timeDisplayFormat))
-	tw.Flush()
+func showCoupon(w io.Writer, v *eclient.Coupon) {
+	couponRecord(v).Write(w)
+}
+
+func couponRecord(v *eclient.Coupon) *output.Table {
+	t := output.NewRecord("Coupon ID", "Coupon Code", "Promo Rule ID",
+		"Promo Rule Code", "Reusable", "Void", "Spent Count", "Created",
+		"Modified")
+	t.Row(v.ID, v.CouponCode, v.PromoRuleID, v.PromoRuleCode, v.Resuable,
+		v.Void, v.SpendCount, v.Created, v.Modified)
+	return t
 }
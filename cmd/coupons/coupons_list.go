@@ -1,74 +1,39 @@
 package coupons
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v", "Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdCouponsList returns new initialized instance of the list sub command
-func NewCmdCouponsList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdCouponsList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "list coupons",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
 			coupons, err := client.GetCoupons(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%v\t%v\t%v\t%v\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Coupon Code", "Promo Rule Code",
-				"Reusable", "Void", "Spend Count",
-				"Created", "Modified")
-			fmt.Fprintf(tw, format, "-----------", "---------------",
-				"--------", "----", "-----------",
-				"-------", "--------")
-
+			t := output.NewTable("Coupon Code", "Promo Rule Code", "Reusable",
+				"Void", "Spend Count", "Created", "Modified").Wide("Coupon ID",
+				"Promo Rule ID")
 			for _, v := range coupons {
-				fmt.Fprintf(tw, format,
-					v.CouponCode, v.PromoRuleCode,
-					v.Void, v.Resuable, v.SpendCount,
-					v.Created.In(location).Format(timeDisplayFormat),
-					v.Modified.In(location).Format(timeDisplayFormat))
+				t.Row(v.CouponCode, v.PromoRuleCode, v.Resuable, v.Void,
+					v.SpendCount, v.Created, v.Modified, v.ID, v.PromoRuleID)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, coupons, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
@@ -1,17 +1,18 @@
 package devkeys
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdDevKeys returns new initialized instance of devkeys sub command
-func NewCmdDevKeys() *cobra.Command {
+func NewCmdDevKeys(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "devkeys",
 		Short: "Developer Keys Management",
 	}
-	cmd.AddCommand(NewCmdDevKeysCreate())
-	cmd.AddCommand(NewCmdDevKeysList())
-	cmd.AddCommand(NewCmdDevKeysDelete())
+	cmd.AddCommand(NewCmdDevKeysCreate(f))
+	cmd.AddCommand(NewCmdDevKeysList(f))
+	cmd.AddCommand(NewCmdDevKeysDelete(f))
 	return cmd
 }
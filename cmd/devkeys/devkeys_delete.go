@@ -1,53 +1,48 @@
 package devkeys
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdDevKeysDelete returns new initialized instance of the delete sub command
-func NewCmdDevKeysDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdDevKeysDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
-		Use:   "delete <coupon_code>",
-		Short: "Delete a coupon",
+		Use:   "delete <developer_key_id>",
+		Short: "Delete a developer key",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// developer_key_id
 			devKeyID := args[0]
 			if !cmdvalidate.IsValidUUID(devKeyID) {
-				fmt.Fprintf(os.Stderr, "developer_key_id %q is not a valid v4 uuid\n", devKeyID)
-				os.Exit(1)
+				return fmt.Errorf("developer_key_id %q is not a valid v4 uuid", devKeyID)
 			}
 
-			ctx := context.Background()
+			if err := f.Confirm(yes, "delete developer key %s", devKeyID); err != nil {
+				return err
+			}
 			err = client.DeleteDeveloperKey(ctx, devKeyID)
-			if err == eclient.ErrDeveloperKeyNotFound {
-				fmt.Fprintf(os.Stderr, "developer key not found. Use ecom devkeys list to check.\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrDeveloperKeyNotFound) {
+				return fmt.Errorf("developer key not found. Use ecom devkeys list to check.")
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
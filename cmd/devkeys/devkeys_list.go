@@ -1,56 +1,32 @@
 package devkeys
 
 import (
-	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v", "Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdDevKeysList returns new initialized instance of list sub command
-func NewCmdDevKeysList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdDevKeysList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list <email>",
 		Short: "List developer keys",
 		Long:  ``,
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			email := args[0]
-			ctx := context.Background()
 			users, err := client.GetUsers(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			userMap := make(map[string]string, 0)
@@ -61,29 +37,21 @@ func NewCmdDevKeysList() *cobra.Command {
 			}
 
 			if _, ok := userMap[email]; !ok {
-				fmt.Fprintf(os.Stderr, "Email %s did not match any users\n", email)
-				os.Exit(1)
+				return fmt.Errorf("email %s did not match any users", email)
 			}
 
 			devKeys, err := client.GetDeveloperKeys(ctx, userMap[email])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Developer Key ID", "Key", "Created")
-			fmt.Fprintf(tw, format, "----------------", "---", "-------")
-
-			for _, devKey := range devKeys {
-				fmt.Fprintf(tw, format,
-					devKey.ID, devKey.Key,
-					devKey.Created.In(location).Format(timeDisplayFormat))
+			t := output.NewTable("Developer Key ID", "Key", "Created").Wide("User ID", "Modified")
+			for _, v := range devKeys {
+				t.Row(v.ID, v.Key, v.Created, v.UserID, v.Modified)
 			}
-
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, devKeys, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
@@ -0,0 +1,224 @@
+// Package display formats times and prices for display, following the
+// preferences of the selected profile. Commands get the preferences
+// applied when the profile is loaded, see cmdutil.Factory; until then,
+// and for profiles without preferences, the defaults apply.
+package display
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+)
+
+// Defaults for profiles without preferences.
+const (
+	DefaultTimezone   = "Europe/London"
+	DefaultTimeFormat = "2006-01-02 15:04"
+	DefaultLocale     = "en-GB"
+	DefaultCurrency   = "GBP"
+)
+
+// locale holds the conventions for writing amounts of money.
+type locale struct {
+	decimal     string
+	group       string
+	symbolAfter bool
+}
+
+var locales = map[string]locale{
+	"en-GB": {decimal: ".", group: ","},
+	"en-IE": {decimal: ".", group: ","},
+	"en-US": {decimal: ".", group: ","},
+	"de-DE": {decimal: ",", group: ".", symbolAfter: true},
+	"es-ES": {decimal: ",", group: ".", symbolAfter: true},
+	"fr-FR": {decimal: ",", group: " ", symbolAfter: true},
+	"it-IT": {decimal: ",", group: ".", symbolAfter: true},
+	"nl-NL": {decimal: ",", group: "."},
+}
+
+var symbols = map[string]string{
+	"GBP": "£",
+	"EUR": "€",
+	"USD": "$",
+	"JPY": "¥",
+}
+
+// zeroDecimal holds the currencies that have no minor unit.
+var zeroDecimal = map[string]bool{
+	"CLP": true,
+	"ISK": true,
+	"JPY": true,
+	"KRW": true,
+	"VND": true,
+}
+
+// settings are the preferences in effect.
+type settings struct {
+	location   *time.Location
+	timeFormat string
+	locale     locale
+	currency   string
+}
+
+var (
+	mu  sync.RWMutex
+	cur *settings
+)
+
+func current() *settings {
+	mu.RLock()
+	s := cur
+	mu.RUnlock()
+	if s != nil {
+		return s
+	}
+	s, err := resolve(configmgr.Preferences{})
+	if err != nil {
+		// without the time zone database fall back to UTC
+		s = &settings{
+			location:   time.UTC,
+			timeFormat: DefaultTimeFormat,
+			locale:     locales[DefaultLocale],
+			currency:   DefaultCurrency,
+		}
+	}
+	mu.Lock()
+	cur = s
+	mu.Unlock()
+	return s
+}
+
+// resolve checks p and fills in the defaults for its empty fields.
+func resolve(p configmgr.Preferences) (*settings, error) {
+	s := settings{
+		timeFormat: p.TimeFormat,
+		currency:   strings.ToUpper(p.Currency),
+	}
+	tz := p.Timezone
+	if tz == "" {
+		tz = DefaultTimezone
+	}
+	var err error
+	if s.location, err = time.LoadLocation(tz); err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", tz)
+	}
+	if s.timeFormat == "" {
+		s.timeFormat = DefaultTimeFormat
+	}
+	name := strings.Replace(p.Locale, "_", "-", 1)
+	if name == "" {
+		name = DefaultLocale
+	}
+	var ok bool
+	if s.locale, ok = locales[name]; !ok {
+		return nil, fmt.Errorf("unsupported locale %q (want one of %s)", p.Locale, strings.Join(Locales(), ", "))
+	}
+	if s.currency == "" {
+		s.currency = DefaultCurrency
+	}
+	if len(s.currency) != 3 {
+		return nil, fmt.Errorf("currency %q is not a 3 letter ISO 4217 code", p.Currency)
+	}
+	return &s, nil
+}
+
+// Locales returns the supported locales.
+func Locales() []string {
+	l := make([]string, 0, len(locales))
+	for k := range locales {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+	return l
+}
+
+// Validate reports whether the preferences can be applied with Set.
+func Validate(p configmgr.Preferences) error {
+	_, err := resolve(p)
+	return err
+}
+
+// Set applies the preferences p to all formatting that follows. Empty
+// fields take the defaults. If p is invalid the formatting is unchanged.
+func Set(p configmgr.Preferences) error {
+	s, err := resolve(p)
+	if err != nil {
+		return err
+	}
+	mu.Lock()
+	cur = s
+	mu.Unlock()
+	return nil
+}
+
+// Location returns the time zone times are shown in.
+func Location() *time.Location {
+	return current().location
+}
+
+// Time returns t in the preferred time zone and format, or an empty
+// string for the zero time.
+func Time(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	s := current()
+	return t.In(s.location).Format(s.timeFormat)
+}
+
+// Price formats an amount in ten-thousandths of the currency unit, as
+// used by the API Service, for example 12345000 GBP is £1,234.50. The
+// amount is shown to two decimal places, or none for currencies without
+// a minor unit such as JPY, unless that would round it. An empty
+// currency means the preferred currency.
+func Price(amount int, currency string) string {
+	s := current()
+	if currency == "" {
+		currency = s.currency
+	}
+	currency = strings.ToUpper(currency)
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	units, frac := amount/10000, amount%10000
+	num := group(units, s.locale.group)
+	switch {
+	case frac == 0 && zeroDecimal[currency]:
+	case frac%100 == 0:
+		num += s.locale.decimal + fmt.Sprintf("%02d", frac/100)
+	default:
+		num += s.locale.decimal + fmt.Sprintf("%04d", frac)
+	}
+
+	sym, ok := symbols[currency]
+	switch {
+	case s.locale.symbolAfter:
+		if !ok {
+			sym = currency
+		}
+		return sign + num + " " + sym
+	case ok:
+		return sign + sym + num
+	}
+	return sign + currency + " " + num
+}
+
+// group writes n with sep between each group of three digits.
+func group(n int, sep string) string {
+	digits := fmt.Sprint(n)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package display
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+)
+
+// set applies p for the rest of the test and restores the defaults
+// afterwards.
+func set(t *testing.T, p configmgr.Preferences) {
+	t.Helper()
+	if err := Set(p); err != nil {
+		t.Fatalf("Set(%+v): %v", p, err)
+	}
+}
+
+func reset() {
+	Set(configmgr.Preferences{})
+}
+
+func TestTime(t *testing.T) {
+	defer reset()
+	winter := time.Date(2020, 1, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2020, 7, 15, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		prefs configmgr.Preferences
+		t     time.Time
+		want  string
+	}{
+		{"default winter", configmgr.Preferences{}, winter, "2020-01-15 12:00"},
+		{"default summer time", configmgr.Preferences{}, summer, "2020-07-15 13:30"},
+		{"new york", configmgr.Preferences{Timezone: "America/New_York"}, winter, "2020-01-15 07:00"},
+		{"new york summer time", configmgr.Preferences{Timezone: "America/New_York"}, summer, "2020-07-15 08:30"},
+		{"tokyo", configmgr.Preferences{Timezone: "Asia/Tokyo"}, winter, "2020-01-15 21:00"},
+		{"utc", configmgr.Preferences{Timezone: "UTC"}, summer, "2020-07-15 12:30"},
+		{"format", configmgr.Preferences{Timezone: "Asia/Kolkata", TimeFormat: time.RFC3339}, winter, "2020-01-15T17:30:00+05:30"},
+		{"zero time", configmgr.Preferences{Timezone: "Asia/Tokyo"}, time.Time{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set(t, tt.prefs)
+			if got := Time(tt.t); got != tt.want {
+				t.Errorf("Time(%s) = %q, want %q", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrice(t *testing.T) {
+	defer reset()
+	tests := []struct {
+		name     string
+		prefs    configmgr.Preferences
+		amount   int
+		currency string
+		want     string
+	}{
+		{"pounds", configmgr.Preferences{}, 12345000, "GBP", "£1,234.50"},
+		{"preferred currency", configmgr.Preferences{Currency: "usd"}, 12345000, "", "$1,234.50"},
+		{"lower case currency", configmgr.Preferences{}, 10000, "eur", "€1.00"},
+		{"zero", configmgr.Preferences{}, 0, "GBP", "£0.00"},
+		{"negative", configmgr.Preferences{}, -2500, "GBP", "-£0.25"},
+		{"not rounded", configmgr.Preferences{}, 12345, "GBP", "£1.2345"},
+		{"millions", configmgr.Preferences{}, 12345678900000, "GBP", "£1,234,567,890.00"},
+		{"no symbol", configmgr.Preferences{}, 10000000, "CHF", "CHF 1,000.00"},
+		{"german", configmgr.Preferences{Locale: "de-DE"}, 12345000, "EUR", "1.234,50 €"},
+		{"german no symbol", configmgr.Preferences{Locale: "de_DE"}, 12345000, "CHF", "1.234,50 CHF"},
+		{"french", configmgr.Preferences{Locale: "fr-FR"}, 12345000, "EUR", "1 234,50 €"},
+		{"dutch", configmgr.Preferences{Locale: "nl-NL"}, 12345000, "EUR", "€1.234,50"},
+		{"yen", configmgr.Preferences{}, 12340000, "JPY", "¥1,234"},
+		{"yen zero", configmgr.Preferences{}, 0, "JPY", "¥0"},
+		{"yen fraction", configmgr.Preferences{}, 12345000, "JPY", "¥1,234.50"},
+		{"won", configmgr.Preferences{}, 500000000, "KRW", "KRW 50,000"},
+		{"yen german", configmgr.Preferences{Locale: "de-DE"}, 12340000, "JPY", "1.234 ¥"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			set(t, tt.prefs)
+			if got := Price(tt.amount, tt.currency); got != tt.want {
+				t.Errorf("Price(%d, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetInvalid(t *testing.T) {
+	defer reset()
+	set(t, configmgr.Preferences{Timezone: "Asia/Tokyo"})
+	tests := []struct {
+		name  string
+		prefs configmgr.Preferences
+	}{
+		{"timezone", configmgr.Preferences{Timezone: "Mars/Olympus_Mons"}},
+		{"locale", configmgr.Preferences{Locale: "xx-XX"}},
+		{"currency", configmgr.Preferences{Currency: "POUNDS"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Set(tt.prefs); err == nil {
+				t.Errorf("Set(%+v) succeeded", tt.prefs)
+			}
+			if err := Validate(tt.prefs); err == nil {
+				t.Errorf("Validate(%+v) succeeded", tt.prefs)
+			}
+			// the formatting is unchanged
+			if loc := Location().String(); loc != "Asia/Tokyo" {
+				t.Errorf("location is %s after a failed Set, want Asia/Tokyo", loc)
+			}
+		})
+	}
+}
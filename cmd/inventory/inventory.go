@@ -1,18 +1,19 @@
 package inventory
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdInventory returns new initialized instance of inventory sub command
-func NewCmdInventory() *cobra.Command {
+func NewCmdInventory(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "inventory",
 		Short: "Inventory management",
 	}
-	cmd.AddCommand(NewCmdInventoryGet())
-	cmd.AddCommand(NewCmdInventoryList())
-	cmd.AddCommand(NewCmdInventoryUpdate())
-	cmd.AddCommand(NewCmdInventoryBatchUpdate())
+	cmd.AddCommand(NewCmdInventoryGet(f))
+	cmd.AddCommand(NewCmdInventoryList(f))
+	cmd.AddCommand(NewCmdInventoryUpdate(f))
+	cmd.AddCommand(NewCmdInventoryBatchUpdate(f))
 	return cmd
 }
@@ -1,49 +1,43 @@
 package inventory
 
 import (
-	"context"
 	"fmt"
 	"io/ioutil"
-	"os"
 	"text/tabwriter"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 )
 
 // NewCmdInventoryBatchUpdate returns new initialized instance of batch-update sub command
-func NewCmdInventoryBatchUpdate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdInventoryBatchUpdate(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "batch-update <inventory.yaml>",
 		Short: "Batch update inventory",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			if err := cmdvalidate.InventoryBatch(args[0], data); err != nil {
+				return err
 			}
 
-			data, err := ioutil.ReadFile(args[0])
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			ctx := context.Background()
 			products, err := client.GetProducts(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			productMap := make(map[string]string, len(products))
@@ -53,19 +47,26 @@ func NewCmdInventoryBatchUpdate() *cobra.Command {
 
 			var invYAML eclient.InventoryBatchContainerYAML
 			if err = yaml.Unmarshal([]byte(data), &invYAML); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
+			// The batch sets absolute onhand values so replaying the
+			// PATCH after a transient failure is safe.
+			policy := client.RetryPolicy()
+			policy.RetryNonIdempotent = true
+			client.SetRetryPolicy(policy)
+
 			req := buildRequest(productMap, &invYAML)
+			if err := f.Confirm(yes, "set the inventory of %d products from %s", len(req), args[0]); err != nil {
+				return err
+			}
 			inv, err := client.UpdateInventoryBatch(ctx, req)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v", err)
-				os.Exit(1)
+				return err
 			}
 
 			format := "%s\t%s\t%v\t%v\t%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t\n",
 				"Inventory ID",
 				"Product SKU",
@@ -86,12 +87,14 @@ func NewCmdInventoryBatchUpdate() *cobra.Command {
 					v.ProductSKU,
 					v.Onhand,
 					v.Overselling,
-					v.Created.In(location).Format(timeDisplayFormat),
-					v.Modified.In(location).Format(timeDisplayFormat))
+					display.Time(v.Created),
+					display.Time(v.Modified))
 			}
 			tw.Flush()
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
 
@@ -1,69 +1,43 @@
 package inventory
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdInventoryList returns new initialized instance of list sub command
-func NewCmdInventoryList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdInventoryList(f *cmdutil.Factory) *cobra.Command {
+	var opts eclient.ListOptions
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "List inventory",
 		Long:  ``,
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
-			inv, err := client.GetAllInventory(ctx)
+			inv, err := client.Inventory(&opts).All(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%v\t%v\t%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t\n",
-				"Inventory ID",
-				"Product SKU",
-				"Onhand",
-				"Overselling",
-				"Created",
-				"Modified")
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t\n",
-				"------------",
-				"-----------",
-				"------",
-				"-----------",
-				"-------",
-				"--------")
+			t := output.NewTable("Inventory ID", "Product SKU", "Onhand",
+				"Overselling", "Created", "Modified").Wide("Product ID",
+				"Product Path")
 			for _, v := range inv {
-				fmt.Fprintf(tw, format,
-					v.ID,
-					v.ProductSKU,
-					v.Onhand,
-					v.Overselling,
-					v.Created.In(location).Format(timeDisplayFormat),
-					v.Modified.In(location).Format(timeDisplayFormat))
+				t.Row(v.ID, v.ProductSKU, v.Onhand, v.Overselling, v.Created,
+					v.Modified, v.ProductID, v.ProductPath)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, inv, t)
 		},
 	}
+	cmdutil.AddListFlags(cmd, &opts)
+	output.AddFlag(cmd, &out)
 	return cmd
 }
@@ -1,13 +1,11 @@
 package inventory
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"strconv"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -15,67 +13,52 @@ import (
 )
 
 // NewCmdInventoryUpdate returns new initialized instance of the update sub command
-func NewCmdInventoryUpdate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdInventoryUpdate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "update <inventory_id>",
 		Short: "Update an individual product inventory",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// inventory command parameter
 			invID := args[0]
 			if !cmdvalidate.IsValidUUID(invID) {
-				fmt.Fprintf(os.Stderr,
-					"inventory_id %q is not a valid v4 uuid\n",
-					invID)
-				os.Exit(1)
+				return fmt.Errorf("inventory_id %q is not a valid v4 uuid", invID)
 			}
 
-			ctx := context.Background()
 			existing, err := client.GetInventory(ctx, invID)
-			if err == eclient.ErrInventoryNotFound {
-				fmt.Fprintf(os.Stderr, "inventory %q not found\n", invID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrInventoryNotFound) {
+				return fmt.Errorf("inventory %q not found", invID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			req, err := promptUpdateInventory(existing)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			// if nothing has changed, then no need to update.
 			if req.Onhand == nil && req.Overselling == nil {
-				os.Exit(0)
+				return nil
 			}
 
 			inventory, err := client.UpdateInventory(ctx, invID, req)
-			if err == eclient.ErrInventoryNotFound {
-				fmt.Fprintf(os.Stderr, "inventory %q not found\n", invID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrInventoryNotFound) {
+				return fmt.Errorf("inventory %q not found", invID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			showInventory(inventory)
+			showInventory(f.IOStreams.Out, inventory)
+			return nil
 		},
 	}
 	return cmd
@@ -112,7 +95,7 @@ func promptUpdateInventory(inv *eclient.Inventory) (*eclient.UpdateInventoryRequ
 
 	onh, err := strconv.Atoi(onhand)
 	if err != nil {
-		return nil, errors.Wrapf(err, "atoi: onhand=%d", onhand)
+		return nil, errors.Wrapf(err, "atoi: onhand=%q", onhand)
 	}
 	// only set { "onhand": v } in the request
 	// if the value has changed.
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+)
+
+// NewCmdLogin returns new initialized instance of the login sub command
+func NewCmdLogin(f *cmdutil.Factory) *cobra.Command {
+	var endpoint, email, name, environment string
+	var passwordStdin, createDevKey, overwrite bool
+	var cmd = &cobra.Command{
+		Use:   "login",
+		Short: "Create a profile by signing in with email and password",
+		Long: `Create a new profile by signing in with an email and password instead
+of a developer key. The refresh token is kept with the profile, so the
+password is not needed again.
+
+The endpoint is taken from the --api-endpoint flag or the ECOM_ENDPOINT
+environment variable, and is prompted for if still unset, as are the
+email and password. Once signed in, login offers to create a developer
+key for the user and save it with the profile.
+
+An existing profile of the same name, or one signed in as the same
+user, is only replaced with --overwrite.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if endpoint == "" {
+				endpoint = os.Getenv(configmgr.EndpointEnv)
+			}
+			var password string
+			if passwordStdin {
+				var err error
+				if password, err = readPassword(f.IOStreams.In); err != nil {
+					return err
+				}
+			}
+			if err := promptLogin(&endpoint, &email, &password); err != nil {
+				return err
+			}
+
+			entry := configmgr.EcomConfigEntry{
+				Endpoint:    endpoint,
+				Environment: environment,
+			}
+			ctx := cmdutil.Context()
+			client, err := cmdutil.NewClient(&entry)
+			if err != nil {
+				return err
+			}
+			g, err := client.GetConfig(ctx)
+			if err != nil {
+				return err
+			}
+			tar, err := client.SignInWithPassword(ctx, g.APIKEY, email, password)
+			if err != nil {
+				return fmt.Errorf("sign in as %s failed: %w", email, err)
+			}
+			client.SetJWT(tar.IDToken)
+			claims, err := eclient.ParseTokenClaims(tar.IDToken)
+			if err != nil {
+				return err
+			}
+
+			entry.Customer = configmgr.Customer{
+				UID:   claims.UID,
+				Role:  claims.Role,
+				Email: email,
+			}
+			// only administrators can list the users
+			if user, err := findUser(ctx, client, claims.UID); err == nil {
+				entry.Customer = configmgr.Customer{
+					ID:        user.ID,
+					UID:       user.UID,
+					Role:      user.Role,
+					Email:     user.Email,
+					Firstname: user.Firstname,
+					Lastname:  user.Lastname,
+				}
+			}
+
+			hostname, err := configmgr.URLToHostName(endpoint)
+			if err != nil {
+				return err
+			}
+			uid := claims.UID
+			if len(uid) > 6 {
+				uid = uid[:6]
+			}
+			entry.Credential = fmt.Sprintf("%s-%s", hostname, uid)
+			if name == "" {
+				name = entry.Credential
+			}
+			cfgs, err := configmgr.ReadConfig()
+			if err != nil {
+				return err
+			}
+			if err := cmdutil.CheckNewProfile(cfgs, name, &entry, overwrite); err != nil {
+				return err
+			}
+
+			if !cmd.Flags().Changed("create-developer-key") && !passwordStdin {
+				createDevKey = confirmLogin("Create a developer key for " + email + "?")
+			}
+			if createDevKey {
+				if entry.Customer.ID == "" {
+					fmt.Fprintf(f.IOStreams.ErrOut, "Warn: developer key not created: user %s not found\n", email)
+				} else {
+					devKey, err := client.CreateDeveloperKey(ctx, &eclient.DevKeyRequest{
+						UserID: entry.Customer.ID,
+					})
+					if err != nil {
+						fmt.Fprintf(f.IOStreams.ErrOut, "Warn: create developer key failed: %v\n", err)
+					} else {
+						entry.DevKey = devKey.Key
+					}
+				}
+			}
+
+			if err := configmgr.WriteTokenAndRefreshToken(entry.Credential, tar); err != nil {
+				return err
+			}
+			err = configmgr.UpdateConfig(func(cfgs *configmgr.EcomConfigurations) error {
+				if err := cmdutil.CheckNewProfile(cfgs, name, &entry, overwrite); err != nil {
+					return err
+				}
+				cfgs.Configurations[name] = entry
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("write config failed: %w", err)
+			}
+			fmt.Fprintf(f.IOStreams.Out, "Signed in as %s. Profile %q created.\n", email, name)
+			if entry.DevKey != "" {
+				fmt.Fprintf(f.IOStreams.Out, "Developer key created and saved with the profile.\n")
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&endpoint, "api-endpoint", "",
+		"API endpoint of the new profile (default from ECOM_ENDPOINT)")
+	cmd.Flags().StringVar(&email, "email", "", "email address to sign in with")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false,
+		"read the password from stdin")
+	cmd.Flags().StringVar(&environment, "environment", "",
+		"tag the profile, for example production to ask for confirmation before destructive commands")
+	cmd.Flags().StringVar(&name, "name", "",
+		"name of the new profile (default <hostname>-<first 6 characters of the user's UID>)")
+	cmd.Flags().BoolVar(&createDevKey, "create-developer-key", false,
+		"create a developer key for the user and save it with the profile (prompted for unless set or --password-stdin is used)")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace an existing profile of the same name")
+	return cmd
+}
+
+// findUser returns the user with the given Firebase UID.
+func findUser(ctx context.Context, client *eclient.EcomClient, uid string) (*eclient.UserResponse, error) {
+	users, err := client.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.UID == uid {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("user with uid %q not found", uid)
+}
+
+// readPassword reads the first line of r.
+func readPassword(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("read password from stdin failed: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", fmt.Errorf("no password on stdin")
+	}
+	return password, nil
+}
+
+// promptLogin prompts for the endpoint, email and password if they are
+// empty.
+func promptLogin(endpoint, email, password *string) error {
+	if *endpoint == "" {
+		e := &survey.Input{
+			Message: "Endpoint:",
+		}
+		if err := survey.AskOne(e, endpoint, survey.Required); err != nil {
+			return err
+		}
+	}
+	if *email == "" {
+		e := &survey.Input{
+			Message: "Email:",
+		}
+		if err := survey.AskOne(e, email, survey.Required); err != nil {
+			return err
+		}
+	}
+	if *password == "" {
+		p := &survey.Password{
+			Message: "Password:",
+		}
+		if err := survey.AskOne(p, password, survey.Required); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func confirmLogin(msg string) bool {
+	prompt := &survey.Confirm{
+		Message: msg,
+	}
+	var answer bool
+	survey.AskOne(prompt, &answer, nil)
+	return answer
+}
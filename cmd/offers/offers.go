@@ -1,18 +1,19 @@
 package offers
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOffers returns new initialized instance of offers sub command
-func NewCmdOffers() *cobra.Command {
+func NewCmdOffers(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "offers",
 		Short: "Offers management",
 	}
-	cmd.AddCommand(NewCmdOffersActivate())
-	cmd.AddCommand(NewCmdOffersList())
-	cmd.AddCommand(NewCmdOffersGet())
-	cmd.AddCommand(NewCmdOffersDeactivate())
+	cmd.AddCommand(NewCmdOffersActivate(f))
+	cmd.AddCommand(NewCmdOffersList(f))
+	cmd.AddCommand(NewCmdOffersGet(f))
+	cmd.AddCommand(NewCmdOffersDeactivate(f))
 	return cmd
 }
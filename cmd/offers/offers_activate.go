@@ -3,55 +3,43 @@ package offers
 import (
 	"context"
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"io"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
-	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdOffersActivate returns new initialized instance of activate sub command
-func NewCmdOffersActivate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOffersActivate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "activate",
 		Short: "Active an offer",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// get the request params
-			ctx := context.Background()
 			req, err := promptCreateOffer(ctx, client)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			offer, err := client.CreateOffer(ctx, req)
-			if err == eclient.ErrOfferExists {
-				fmt.Fprintf(os.Stderr,
-					"offer with promo rule id %s already active\n",
-					req.PromoRuleID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrOfferExists) {
+				return fmt.Errorf("offer with promo rule id %s already active", req.PromoRuleID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
-			showOffer(offer)
+			showOffer(f.IOStreams.Out, offer)
+			return nil
 		},
 	}
 	return cmd
@@ -85,13 +73,13 @@ func promptCreateOffer(ctx context.Context, client *eclient.EcomClient) (*eclien
 	return &req, nil
 }
 
-func showOffer(v *eclient.Offer) {
-	format := "%v\t%v\t\n"
-	tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, format, "Offer ID:", v.ID)
-	fmt.Fprintf(tw, format, "Promo Rule ID:", v.PromoRuleID)
-	fmt.Fprintf(tw, format, "Promo Rule Code:", "todo")
-	fmt.Fprintf(tw, format, "Created:", v.Created.In(service.Location).Format(service.TimeDisplayFormat))
-	fmt.Fprintf(tw, format, "Modified:", v.Modified.In(service.Location).Format(service.TimeDisplayFormat))
-	tw.Flush()
+func showOffer(w io.Writer, v *eclient.Offer) {
+	offerRecord(v).Write(w)
+}
+
+func offerRecord(v *eclient.Offer) *output.Table {
+	t := output.NewRecord("Offer ID", "Promo Rule ID", "Promo Rule Code",
+		"Created", "Modified")
+	t.Row(v.ID, v.PromoRuleID, v.PromoRuleCode, v.Created, v.Modified)
+	return t
 }
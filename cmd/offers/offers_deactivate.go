@@ -1,55 +1,48 @@
 package offers
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOffersDeactivate returns new initialized instance of the deactivate sub command
-func NewCmdOffersDeactivate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOffersDeactivate(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "deactivate <offer_id>",
 		Short: "Deactive an offer",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// offer_id
 			offerID := args[0]
 			if !cmdvalidate.IsValidUUID(offerID) {
-				fmt.Fprintf(os.Stderr, "offer_id %q is not a valid v4 uuid\n",
-					offerID)
-				os.Exit(1)
+				return fmt.Errorf("offer_id %q is not a valid v4 uuid", offerID)
 			}
 
-			ctx := context.Background()
+			if err := f.Confirm(yes, "deactivate offer %s", offerID); err != nil {
+				return err
+			}
 			err = client.DeleteOffer(ctx, offerID)
-			if err == eclient.ErrOfferNotFound {
-				fmt.Fprintf(os.Stderr,
-					"offer not found. Use ecom offers list to check.\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrOfferNotFound) {
+				return fmt.Errorf("offer not found. Use ecom offers list to check.")
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
@@ -1,53 +1,45 @@
 package offers
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOffersGet returns new initialized instance of the get sub command
-func NewCmdOffersGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOffersGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get <offer_id>",
 		Short: "Get an offer by id",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			offerID := args[0]
 			if !cmdvalidate.IsValidUUID(offerID) {
-				fmt.Fprintf(os.Stderr, "offer_id %q is not a valid v4 uuid\n", offerID)
-				os.Exit(1)
+				return fmt.Errorf("offer_id %q is not a valid v4 uuid", offerID)
 			}
 
-			ctx := context.Background()
 			offer, err := client.GetOffer(ctx, offerID)
-			if err == eclient.ErrOfferNotFound {
-				fmt.Fprintf(os.Stderr, "offer %q not found\n", offerID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrOfferNotFound) {
+				return fmt.Errorf("offer %q not found", offerID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
-			showOffer(offer)
+			return out.Print(f.IOStreams.Out, offer, offerRecord(offer))
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
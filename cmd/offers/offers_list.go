@@ -1,67 +1,38 @@
 package offers
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
-	"github.com/ecommerce-builder/ecom-cli-tool/service"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOffersList returns new initialized instance of list sub command
-func NewCmdOffersList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOffersList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "List offers",
 		Long:  ``,
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
 			offers, err := client.GetOffers(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%v\t%v\t%v\t%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n",
-				"Offer ID",
-				"Promo Rule Code",
-				"Promo Rule ID",
-				"Created",
-				"Modified")
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n",
-				"--------",
-				"---------------",
-				"-------------",
-				"-------",
-				"--------")
+			t := output.NewTable("Offer ID", "Promo Rule Code", "Promo Rule ID",
+				"Created", "Modified")
 			for _, v := range offers {
-				fmt.Fprintf(tw, format,
-					v.ID,
-					v.PromoRuleCode,
-					v.PromoRuleID,
-					v.Created.In(service.Location).Format(service.TimeDisplayFormat),
-					v.Modified.In(service.Location).Format(service.TimeDisplayFormat))
+				t.Row(v.ID, v.PromoRuleCode, v.PromoRuleID, v.Created, v.Modified)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, offers, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
@@ -1,18 +1,19 @@
 package orders
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOrders returns new initialized instance of orders sub command
-func NewCmdOrders() *cobra.Command {
+func NewCmdOrders(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "orders",
 		Short: "Orders management",
 	}
-	cmd.AddCommand(NewCmdOrdersCreate())
-	cmd.AddCommand(NewCmdOrdersGet())
-	cmd.AddCommand(NewCmdOrdersList())
-	cmd.AddCommand(NewCmdOrdersStripeCheckout())
+	cmd.AddCommand(NewCmdOrdersCreate(f))
+	cmd.AddCommand(NewCmdOrdersGet(f))
+	cmd.AddCommand(NewCmdOrdersList(f))
+	cmd.AddCommand(NewCmdOrdersStripeCheckout(f))
 	return cmd
 }
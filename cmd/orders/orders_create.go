@@ -1,63 +1,58 @@
 package orders
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdOrdersCreate returns new initialized instance of the create sub command
-func NewCmdOrdersCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOrdersCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "create <cart_id>",
+		Use:   "create [cart_id]",
 		Short: "Place an order for a cart",
-		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		Long: `Place an order for a cart. Without a cart_id the cart is taken from
+the ECOM_CLI_CART_ID environment variable or the cart preference of the
+profile.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			cartID := args[0]
-			if !cmdvalidate.IsValidUUID(cartID) {
-				fmt.Fprintf(os.Stderr, "cart_id %q is not a valid v4 uuid\n",
-					cartID)
-				os.Exit(1)
+			var cartID string
+			if len(args) > 0 {
+				cartID = args[0]
+				if !cmdvalidate.IsValidUUID(cartID) {
+					return fmt.Errorf("cart_id %q is not a valid v4 uuid", cartID)
+				}
+			} else if cartID, err = f.CartID(); err != nil {
+				return err
 			}
 
 			req, err := promptCreateOrder()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			req.CartID = &cartID
-			fmt.Println(req)
+			fmt.Fprintln(f.IOStreams.Out, req)
 
-			ctx := context.Background()
 			order, err := client.PlaceOrder(ctx, req)
-			if err == eclient.ErrCartNotFound {
-				fmt.Fprintf(os.Stderr, "cart %q not found\n", cartID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrCartNotFound) {
+				return fmt.Errorf("cart %q not found", cartID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
-			showOrder(order)
+			showOrder(f.IOStreams.Out, order)
+			return nil
 		},
 	}
 	return cmd
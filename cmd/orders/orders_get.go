@@ -1,63 +1,72 @@
 package orders
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
+	"io"
 	"text/tabwriter"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
-	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOrdersGet returns new initialized instance of the get sub command
-func NewCmdOrdersGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOrdersGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get <offer_id>",
 		Short: "Get an order by id",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			orderID := args[0]
 			if !cmdvalidate.IsValidUUID(orderID) {
-				fmt.Fprintf(os.Stderr, "order_id %q is not a valid v4 uuid\n",
-					orderID)
-				os.Exit(1)
+				return fmt.Errorf("order_id %q is not a valid v4 uuid", orderID)
 			}
 
-			ctx := context.Background()
 			order, err := client.GetOrder(ctx, orderID)
-			if err == eclient.ErrOrderNotFound {
-				fmt.Fprintf(os.Stderr, "order %q not found\n", orderID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrOrderNotFound) {
+				return fmt.Errorf("order %q not found", orderID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+			if out.Human() {
+				showOrder(f.IOStreams.Out, order)
+				return nil
 			}
-			showOrder(order)
+			return out.Print(f.IOStreams.Out, order, orderRecord(order))
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
 
-func showOrder(v *eclient.Order) {
+// orderRecord returns the order without its addresses and items, for
+// the csv format. showOrder prints the full order.
+func orderRecord(v *eclient.Order) *output.Table {
+	t := output.NewRecord("ID", "Order ID", "Status", "Payment",
+		"Contact name", "Email", "Currency", "Total ex VAT", "VAT Total",
+		"Total inc VAT", "Created", "Modified")
+	t.Row(v.ID, v.OrderID, v.Status, v.Payment, v.User.ContactName,
+		v.User.Email, v.Currency, display.Price(v.TotalExVAT, v.Currency),
+		display.Price(v.VATTotal, v.Currency),
+		display.Price(v.TotalIncVAT, v.Currency), v.Created, v.Modified)
+	return t
+}
+
+func showOrder(w io.Writer, v *eclient.Order) {
 	format := "%v\t%v\t\n"
-	tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+	tw := new(tabwriter.Writer).Init(w, 0, 8, 2, ' ', 0)
 	fmt.Fprintf(tw, format, "ID:", v.ID)
 	fmt.Fprintf(tw, format, "Order ID:", v.OrderID)
 	fmt.Fprintf(tw, format, "Status:", v.Status)
@@ -65,8 +74,8 @@ func showOrder(v *eclient.Order) {
 	fmt.Fprintf(tw, format, "Contact name:", v.User.ContactName)
 	fmt.Fprintf(tw, format, "Email:", v.User.Email)
 	fmt.Fprintf(tw, format, "Currency:", v.Currency)
-	fmt.Fprintf(tw, format, "Created:", v.Created.In(service.Location).Format(service.TimeDisplayFormat))
-	fmt.Fprintf(tw, format, "Modified:", v.Modified.In(service.Location).Format(service.TimeDisplayFormat))
+	fmt.Fprintf(tw, format, "Created:", display.Time(v.Created))
+	fmt.Fprintf(tw, format, "Modified:", display.Time(v.Modified))
 
 	fmt.Fprintln(tw)
 	fmt.Fprintf(tw, format, "Billing address", "")
@@ -100,25 +109,25 @@ func showOrder(v *eclient.Order) {
 		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t\n",
 			v.Qty,
 			v.SKU,
-			service.IntPriceToString(v.UnitPrice),
+			display.Price(v.UnitPrice, v.Currency),
 			v.TaxCode,
-			service.IntPriceToString(v.VAT),
-			service.IntPriceToString(v.VAT+v.UnitPrice))
+			display.Price(v.VAT, v.Currency),
+			display.Price(v.VAT+v.UnitPrice, v.Currency))
 	}
 	fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t\n",
 		"", "", "", "", "", "")
 	fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t\n",
 		"", "", "", "",
 		"Subtotal",
-		service.IntPriceToString(v.TotalExVAT))
+		display.Price(v.TotalExVAT, v.Currency))
 	fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t\n",
 		"", "", "", "",
 		"Total VAT",
-		service.IntPriceToString(v.VATTotal))
+		display.Price(v.VATTotal, v.Currency))
 	fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t\n",
 		"", "", "", "",
 		"Total",
-		service.IntPriceToString(v.TotalIncVAT))
+		display.Price(v.TotalIncVAT, v.Currency))
 	tw.Flush()
 }
 
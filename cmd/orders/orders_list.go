@@ -1,85 +1,49 @@
 package orders
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
-	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOrdersList returns new initialized instance of list sub command
-func NewCmdOrdersList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOrdersList(f *cmdutil.Factory) *cobra.Command {
+	var opts eclient.ListOptions
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "List orders",
 		Long:  ``,
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
-			orders, err := client.GetOrders(ctx)
+			orders, err := client.Orders(&opts).All(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
-				"ID",
-				"Order ID",
-				"Status",
-				"Payment",
-				"Contact name",
-				"Email",
-				"Currency",
-				"Total ex VAT",
-				"VAT Total",
-				"Total inc VAT",
-				"Created")
-			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t\n",
-				"--------",
-				"-----------",
-				"------",
-				"-------",
-				"------------",
-				"-----",
-				"--------",
-				"------------",
-				"---------",
-				"-------------",
-				"-------")
+			t := output.NewTable("ID", "Order ID", "Status", "Payment",
+				"Contact name", "Email", "Currency", "Total ex VAT",
+				"VAT Total", "Total inc VAT", "Created").Wide("User ID",
+				"Items", "Modified")
 			for _, v := range orders {
-				fmt.Fprintf(tw, format,
-					v.ID,
-					v.OrderID,
-					v.Status,
-					v.Payment,
-					v.User.ContactName,
-					v.User.Email,
-					v.Currency,
-					service.IntPriceToString(v.TotalExVAT),
-					service.IntPriceToString(v.VATTotal),
-					service.IntPriceToString(v.TotalIncVAT),
-					v.Created.In(service.Location).Format(service.TimeDisplayFormat))
+				t.Row(v.ID, v.OrderID, v.Status, v.Payment, v.User.ContactName,
+					v.User.Email, v.Currency,
+					display.Price(v.TotalExVAT, v.Currency),
+					display.Price(v.VATTotal, v.Currency),
+					display.Price(v.TotalIncVAT, v.Currency), v.Created,
+					v.User.UserID, len(v.Items), v.Modified)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, orders, t)
 		},
 	}
+	cmdutil.AddListFlags(cmd, &opts)
+	output.AddFlag(cmd, &out)
 	return cmd
 }
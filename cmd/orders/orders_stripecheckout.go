@@ -1,49 +1,37 @@
 package orders
 
 import (
-	"context"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdOrdersStripeCheckout returns new initialized instance of the stripecheckout sub command
-func NewCmdOrdersStripeCheckout() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdOrdersStripeCheckout(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "stripecheckout <order_id>",
 		Short: "Stripe checkout an order",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			orderID := args[0]
 			if !cmdvalidate.IsValidUUID(orderID) {
-				fmt.Fprintf(os.Stderr, "order_id %q is not a valid v4 uuid\n",
-					orderID)
-				os.Exit(1)
+				return fmt.Errorf("order_id %q is not a valid v4 uuid", orderID)
 			}
 
-			ctx := context.Background()
 			sessionID, err := client.StripeCheckout(ctx, orderID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
-			fmt.Printf("Stripe checkout id: %s\n", sessionID)
+			fmt.Fprintf(f.IOStreams.Out, "Stripe checkout id: %s\n", sessionID)
+			return nil
 		},
 	}
 	return cmd
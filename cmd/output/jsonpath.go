@@ -0,0 +1,254 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// jsonPath is a parsed JSONPath template. It supports the subset of the
+// kubectl syntax useful for picking fields out of API resources: text
+// outside braces is printed as is and each {expression} is replaced by
+// its results separated by spaces. Expressions are made of
+//
+//	$        the root (optional)
+//	.name    a field
+//	['name'] a field whose name contains dots or spaces
+//	[n]      an array element, negative n counts from the end
+//	[*] .*   every array element or field value
+//	..name   name at any depth
+//
+// and {"text"} prints the quoted Go string, so {"\n"} prints a newline.
+// Filters, unions, slices and range are not supported.
+type jsonPath struct {
+	parts []jsonPathPart
+}
+
+// jsonPathPart is either literal text or an expression.
+type jsonPathPart struct {
+	text  string
+	steps []jsonPathStep
+	expr  bool
+}
+
+type jsonPathStep struct {
+	name      string
+	index     int
+	isIndex   bool
+	wildcard  bool
+	recursive bool
+}
+
+func parseJSONPath(s string) (*jsonPath, error) {
+	jp := &jsonPath{}
+	for len(s) > 0 {
+		i := strings.IndexByte(s, '{')
+		if i < 0 {
+			jp.parts = append(jp.parts, jsonPathPart{text: s})
+			break
+		}
+		if i > 0 {
+			jp.parts = append(jp.parts, jsonPathPart{text: s[:i]})
+		}
+		j := strings.IndexByte(s[i:], '}')
+		if j < 0 {
+			return nil, errors.Errorf("unclosed { at offset %d", i)
+		}
+		expr := strings.TrimSpace(s[i+1 : i+j])
+		s = s[i+j+1:]
+
+		if strings.HasPrefix(expr, `"`) {
+			text, err := strconv.Unquote(expr)
+			if err != nil {
+				return nil, errors.Errorf("bad string literal %s", expr)
+			}
+			jp.parts = append(jp.parts, jsonPathPart{text: text})
+			continue
+		}
+		steps, err := parseSteps(expr)
+		if err != nil {
+			return nil, err
+		}
+		jp.parts = append(jp.parts, jsonPathPart{steps: steps, expr: true})
+	}
+	return jp, nil
+}
+
+func parseSteps(expr string) ([]jsonPathStep, error) {
+	s := strings.TrimPrefix(expr, "$")
+	if s != "" && s[0] != '.' && s[0] != '[' {
+		s = "." + s
+	}
+
+	var steps []jsonPathStep
+	for len(s) > 0 {
+		switch {
+		case strings.HasPrefix(s, ".."):
+			name, rest := splitName(s[2:])
+			if name == "" {
+				return nil, errors.Errorf("%q: expected a field name after ..", expr)
+			}
+			steps = append(steps, jsonPathStep{name: name, recursive: true})
+			s = rest
+		case s[0] == '.':
+			name, rest := splitName(s[1:])
+			switch name {
+			case "":
+				// "{.}" is the current value
+			case "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			default:
+				steps = append(steps, jsonPathStep{name: name})
+			}
+			s = rest
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, errors.Errorf("%q: unclosed [", expr)
+			}
+			sub := strings.TrimSpace(s[1:end])
+			s = s[end+1:]
+			switch {
+			case sub == "*":
+				steps = append(steps, jsonPathStep{wildcard: true})
+			case len(sub) >= 2 && (sub[0] == '\'' || sub[0] == '"') && sub[len(sub)-1] == sub[0]:
+				steps = append(steps, jsonPathStep{name: sub[1 : len(sub)-1]})
+			default:
+				n, err := strconv.Atoi(sub)
+				if err != nil {
+					return nil, errors.Errorf("%q: unsupported subscript [%s]", expr, sub)
+				}
+				steps = append(steps, jsonPathStep{index: n, isIndex: true})
+			}
+		default:
+			return nil, errors.Errorf("%q: unexpected %q", expr, s)
+		}
+	}
+	return steps, nil
+}
+
+// splitName splits a field name from the front of s. A stray ] ends
+// the name so that it is reported as unexpected.
+func splitName(s string) (name, rest string) {
+	i := strings.IndexAny(s, ".[]")
+	if i < 0 {
+		return s, ""
+	}
+	return s[:i], s[i:]
+}
+
+func (jp *jsonPath) execute(w io.Writer, data interface{}) error {
+	for _, p := range jp.parts {
+		if !p.expr {
+			if _, err := io.WriteString(w, p.text); err != nil {
+				return err
+			}
+			continue
+		}
+		values := []interface{}{data}
+		for _, st := range p.steps {
+			values = st.apply(values)
+		}
+		out := make([]string, 0, len(values))
+		for _, v := range values {
+			s, err := text(v)
+			if err != nil {
+				return err
+			}
+			out = append(out, s)
+		}
+		if _, err := io.WriteString(w, strings.Join(out, " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (st jsonPathStep) apply(values []interface{}) []interface{} {
+	var out []interface{}
+	for _, v := range values {
+		switch {
+		case st.recursive:
+			out = append(out, descend(v, st.name)...)
+		case st.wildcard:
+			switch t := v.(type) {
+			case []interface{}:
+				out = append(out, t...)
+			case map[string]interface{}:
+				for _, k := range sortedKeys(t) {
+					out = append(out, t[k])
+				}
+			}
+		case st.isIndex:
+			if a, ok := v.([]interface{}); ok {
+				i := st.index
+				if i < 0 {
+					i += len(a)
+				}
+				if i >= 0 && i < len(a) {
+					out = append(out, a[i])
+				}
+			}
+		default:
+			if m, ok := v.(map[string]interface{}); ok {
+				if f, ok := m[st.name]; ok {
+					out = append(out, f)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// descend returns the values of every field called name in v, at any
+// depth.
+func descend(v interface{}, name string) []interface{} {
+	var out []interface{}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if f, ok := t[name]; ok {
+			out = append(out, f)
+		}
+		for _, k := range sortedKeys(t) {
+			out = append(out, descend(t[k], name)...)
+		}
+	case []interface{}:
+		for _, e := range t {
+			out = append(out, descend(e, name)...)
+		}
+	}
+	return out
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// text returns v as printed by a JSONPath expression: strings and
+// numbers as is, objects and arrays as JSON.
+func text(v interface{}) (string, error) {
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return t, nil
+	case json.Number:
+		return t.String(), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", errors.Wrap(err, "json marshal")
+	}
+	return string(b), nil
+}
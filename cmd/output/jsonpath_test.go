@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const jsonPathData = `{
+  "sku": "A",
+  "name": "Apple",
+  "price.list": "default",
+  "tags": ["red", "fruit"],
+  "stock": {"onhand": 10, "overselling": false},
+  "images": [
+    {"path": "a.jpg", "size": {"w": 100}},
+    {"path": "b.jpg", "size": {"w": 200}}
+  ],
+  "empty": null
+}`
+
+func TestJSONPath(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"{.sku}", "A"},
+		{"{$.sku}", "A"},
+		{"{sku}", "A"},
+		{"sku={.sku} name={.name}", "sku=A name=Apple"},
+		{"{.stock.onhand}", "10"},
+		{"{.stock.overselling}", "false"},
+		{"{['price.list']}", "default"},
+		{`{["price.list"]}`, "default"},
+		{"{.tags[0]}", "red"},
+		{"{.tags[-1]}", "fruit"},
+		{"{.tags[5]}", ""},
+		{"{.tags[*]}", "red fruit"},
+		{"{.tags}", `["red","fruit"]`},
+		{"{.images[*].path}", "a.jpg b.jpg"},
+		{"{.images[1].size.w}", "200"},
+		{"{.stock.*}", "10 false"},
+		{"{..w}", "100 200"},
+		{"{..path}", "a.jpg b.jpg"},
+		{"{.stock}", `{"onhand":10,"overselling":false}`},
+		{"{.}", "A"},
+		{"{.missing}", ""},
+		{"{.missing.deeper}", ""},
+		{"{.sku[0]}", ""},
+		{"{.empty}", ""},
+		{`{.sku}{"\n"}`, "A\n"},
+		{"no expressions", "no expressions"},
+	}
+	var data map[string]interface{}
+	dec := json.NewDecoder(strings.NewReader(jsonPathData))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		jp, err := parseJSONPath(tt.expr)
+		if err != nil {
+			t.Errorf("parseJSONPath(%q): %v", tt.expr, err)
+			continue
+		}
+		var b bytes.Buffer
+		v := interface{}(data)
+		if tt.expr == "{.}" {
+			v = data["sku"]
+		}
+		if err := jp.execute(&b, v); err != nil {
+			t.Errorf("execute(%q): %v", tt.expr, err)
+			continue
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s = %q, want %q", tt.expr, b.String(), tt.want)
+		}
+	}
+}
+
+func TestJSONPathMalformed(t *testing.T) {
+	tests := []struct {
+		expr string
+		err  string
+	}{
+		{"{.sku", "unclosed { at offset 0"},
+		{"x {.sku", "unclosed { at offset 2"},
+		{"{.tags[0}", "unclosed ["},
+		{"{.tags[1:2]}", "unsupported subscript [1:2]"},
+		{"{.tags[?(@.x)]}", "unsupported subscript"},
+		{"{..}", "expected a field name after .."},
+		{`{"unterminated}`, "bad string literal"},
+		{"{.a]}", `unexpected "]"`},
+	}
+	for _, tt := range tests {
+		_, err := parseJSONPath(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("parseJSONPath(%q) error = %v, want one containing %q", tt.expr, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Package output renders the results of get and list commands in the
+// format selected with the --output (-o) flag:
+//
+//	table              columns chosen for reading in a terminal (default)
+//	wide               table with additional columns
+//	json               the full API resources as JSON
+//	yaml               the full API resources as YAML
+//	csv                every table column, with a header row
+//	template=TEMPLATE  a Go text/template applied to the JSON resources
+//	jsonpath=EXPR      a JSONPath expression applied to the JSON resources
+//
+// Templates and JSONPath expressions address fields by their JSON names,
+// for example -o template='{{range .}}{{.sku}}{{"\n"}}{{end}}' or
+// -o jsonpath='{[*].sku}'.
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Formats lists the values accepted by the --output flag.
+var Formats = []string{"table", "wide", "json", "yaml", "csv", "template=", "jsonpath="}
+
+// Printer prints command results in the format selected with --output.
+type Printer struct {
+	format string
+}
+
+// AddFlag adds the --output (-o) flag to cmd, selecting the format used
+// by p.
+func AddFlag(cmd *cobra.Command, p *Printer) {
+	cmd.Flags().StringVarP(&p.format, "output", "o", "table",
+		"output format: "+strings.Join(Formats, "|"))
+}
+
+// Human reports whether the table or wide format is selected, for
+// commands whose table view is more than a single Table.
+func (p *Printer) Human() bool {
+	return p.format == "" || p.format == "table" || p.format == "wide"
+}
+
+// Print writes v to w in the selected format. v is the API resource, or
+// slice of resources, and is used for the json, yaml, template and
+// jsonpath formats. t is the table view of v, used for the table, wide
+// and csv formats; it may be nil if the command has its own table view,
+// see Human.
+func (p *Printer) Print(w io.Writer, v interface{}, t *Table) error {
+	format, arg := p.format, ""
+	if i := strings.IndexByte(format, '='); i >= 0 {
+		format, arg = format[:i], format[i+1:]
+	}
+	if t == nil && (p.Human() || format == "csv") {
+		return errors.Errorf("output format %q is not supported by this command", p.format)
+	}
+
+	// an empty list prints as [] rather than null
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.IsNil() {
+		v = reflect.MakeSlice(rv.Type(), 0, 0).Interface()
+	}
+
+	switch format {
+	case "", "table":
+		return t.write(w, false)
+	case "wide":
+		return t.write(w, true)
+	case "csv":
+		return t.writeCSV(w)
+	case "json":
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "json marshal")
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	case "yaml":
+		doc, err := generic(v, true)
+		if err != nil {
+			return err
+		}
+		b, err := yaml.Marshal(doc)
+		if err != nil {
+			return errors.Wrap(err, "yaml marshal")
+		}
+		_, err = w.Write(b)
+		return err
+	case "template", "go-template":
+		tmpl, err := template.New("output").Parse(arg)
+		if err != nil {
+			return errors.Wrap(err, "parse template")
+		}
+		data, err := generic(v, false)
+		if err != nil {
+			return err
+		}
+		return tmpl.Execute(w, data)
+	case "jsonpath":
+		jp, err := parseJSONPath(arg)
+		if err != nil {
+			return errors.Wrap(err, "parse jsonpath")
+		}
+		data, err := generic(v, false)
+		if err != nil {
+			return err
+		}
+		return jp.execute(w, data)
+	}
+	return errors.Errorf("unknown output format %q (want one of %s)",
+		p.format, strings.Join(Formats, ", "))
+}
+
+// generic converts v to the values produced by decoding its JSON
+// encoding, so templates, JSONPath expressions and YAML all use the JSON
+// field names. If ordered is set, objects are decoded as yaml.MapSlice to
+// keep the field order of the JSON encoding.
+func generic(v interface{}, ordered bool) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "json marshal")
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if !ordered {
+		var out interface{}
+		if err := dec.Decode(&out); err != nil {
+			return nil, errors.Wrap(err, "json decode")
+		}
+		return out, nil
+	}
+	return decodeOrdered(dec)
+}
+
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, errors.Wrap(err, "json decode")
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		switch t {
+		case '{':
+			m := yaml.MapSlice{}
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, errors.Wrap(err, "json decode")
+				}
+				val, err := decodeOrdered(dec)
+				if err != nil {
+					return nil, err
+				}
+				m = append(m, yaml.MapItem{Key: key, Value: val})
+			}
+			_, err = dec.Token() // '}'
+			return m, err
+		case '[':
+			s := []interface{}{}
+			for dec.More() {
+				val, err := decodeOrdered(dec)
+				if err != nil {
+					return nil, err
+				}
+				s = append(s, val)
+			}
+			_, err = dec.Token() // ']'
+			return s, err
+		}
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return i, nil
+		}
+		f, _ := t.Float64()
+		return f, nil
+	}
+	return tok, nil
+}
@@ -0,0 +1,161 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type product struct {
+	ID    string   `json:"id"`
+	SKU   string   `json:"sku"`
+	Name  string   `json:"name"`
+	Tags  []string `json:"tags"`
+	Price *int     `json:"price,omitempty"`
+}
+
+func productsTable(products []product) *Table {
+	t := NewTable("ID", "SKU", "Name").Wide("Tags", "Price")
+	for _, p := range products {
+		t.Row(p.ID, p.SKU, p.Name, p.Tags, p.Price)
+	}
+	return t
+}
+
+func TestPrint(t *testing.T) {
+	price := 1250
+	products := []product{
+		{ID: "1", SKU: "A", Name: "Apple", Tags: []string{"red", "fruit"}, Price: &price},
+		{ID: "2", SKU: "B", Name: "Banana"},
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"table", `ID  SKU  Name
+--  ---  ----
+1   A    Apple
+2   B    Banana
+`},
+		{"", `ID  SKU  Name
+--  ---  ----
+1   A    Apple
+2   B    Banana
+`},
+		{"wide", `ID  SKU  Name    Tags       Price
+--  ---  ----    ----       -----
+1   A    Apple   red,fruit  1250
+2   B    Banana  -          -
+`},
+		{"csv", `ID,SKU,Name,Tags,Price
+1,A,Apple,"red,fruit",1250
+2,B,Banana,,
+`},
+		{"json", `[
+  {
+    "id": "1",
+    "sku": "A",
+    "name": "Apple",
+    "tags": [
+      "red",
+      "fruit"
+    ],
+    "price": 1250
+  },
+  {
+    "id": "2",
+    "sku": "B",
+    "name": "Banana",
+    "tags": null
+  }
+]
+`},
+		{"yaml", `- id: "1"
+  sku: A
+  name: Apple
+  tags:
+  - red
+  - fruit
+  price: 1250
+- id: "2"
+  sku: B
+  name: Banana
+  tags: null
+`},
+		{`template={{range .}}{{.sku}}={{.name}};{{end}}`, "A=Apple;B=Banana;"},
+		{`jsonpath={[*].sku}`, "A B"},
+		{`jsonpath={[0].tags[1]}`, "fruit"},
+		{`jsonpath={[*].price}`, "1250"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			p := Printer{format: tt.format}
+			var b bytes.Buffer
+			if err := p.Print(&b, products, productsTable(products)); err != nil {
+				t.Fatalf("Print: %v", err)
+			}
+			if b.String() != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", b.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintRecord(t *testing.T) {
+	rec := NewRecord("SKU", "Name").Wide("Tags")
+	rec.Row("A", "Apple", []string{"red"})
+	var b bytes.Buffer
+	if err := (&Printer{format: "table"}).Print(&b, nil, rec); err != nil {
+		t.Fatal(err)
+	}
+	want := "SKU:   A      \nName:  Apple  \n"
+	if b.String() != want {
+		t.Errorf("got %q, want %q", b.String(), want)
+	}
+}
+
+func TestPrintEmptyList(t *testing.T) {
+	var products []product
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"json", "[]\n"},
+		{"yaml", "[]\n"},
+		{"table", "ID  SKU  Name\n--  ---  ----\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := (&Printer{format: tt.format}).Print(&b, products, productsTable(products)); err != nil {
+			t.Fatalf("%s: %v", tt.format, err)
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.format, b.String(), tt.want)
+		}
+	}
+}
+
+func TestPrintErrors(t *testing.T) {
+	tests := []struct {
+		format string
+		table  bool
+		err    string
+	}{
+		{"xml", true, `unknown output format "xml"`},
+		{"table", false, `output format "table" is not supported by this command`},
+		{"csv", false, `output format "csv" is not supported by this command`},
+		{"template={{.sku", true, "parse template"},
+		{"jsonpath={.sku", true, "parse jsonpath: unclosed {"},
+	}
+	for _, tt := range tests {
+		var table *Table
+		if tt.table {
+			table = NewTable("SKU")
+		}
+		err := (&Printer{format: tt.format}).Print(&bytes.Buffer{}, product{SKU: "A"}, table)
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("Print -o %s error = %v, want one containing %q", tt.format, err, tt.err)
+		}
+	}
+}
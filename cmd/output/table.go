@@ -0,0 +1,147 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+)
+
+// Table is the tabular view of a command's result.
+type Table struct {
+	headers  []string
+	wide     []bool
+	rows     [][]string
+	vertical bool
+}
+
+// NewTable returns a table with the given columns, one row per resource.
+func NewTable(headers ...string) *Table {
+	return &Table{
+		headers: headers,
+		wide:    make([]bool, len(headers)),
+	}
+}
+
+// NewRecord returns a table for a single resource, printed with one
+// "Header: value" line per column.
+func NewRecord(headers ...string) *Table {
+	t := NewTable(headers...)
+	t.vertical = true
+	return t
+}
+
+// Wide adds columns that are only printed with -o wide and -o csv.
+func (t *Table) Wide(headers ...string) *Table {
+	for _, h := range headers {
+		t.headers = append(t.headers, h)
+		t.wide = append(t.wide, true)
+	}
+	return t
+}
+
+// Row appends a row of cells, one for each column including the wide
+// ones. Times are shown in the local display format, slices as comma
+// separated values and nil pointers as empty cells.
+func (t *Table) Row(cells ...interface{}) {
+	row := make([]string, len(t.headers))
+	for i := range row {
+		if i < len(cells) {
+			row[i] = cell(cells[i])
+		}
+	}
+	t.rows = append(t.rows, row)
+}
+
+func cell(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return ""
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return ""
+	}
+	if tm, ok := rv.Interface().(time.Time); ok {
+		if tm.IsZero() {
+			return ""
+		}
+		return display.Time(tm)
+	}
+	if rv.Kind() == reflect.Slice {
+		s := make([]string, rv.Len())
+		for i := range s {
+			s[i] = cell(rv.Index(i).Interface())
+		}
+		return strings.Join(s, ",")
+	}
+	return fmt.Sprint(rv.Interface())
+}
+
+// Write writes t to w in the table format, for commands without an
+// --output flag.
+func (t *Table) Write(w io.Writer) error {
+	return t.write(w, false)
+}
+
+func (t *Table) write(w io.Writer, wide bool) error {
+	var cols []int
+	for i := range t.headers {
+		if wide || !t.wide[i] {
+			cols = append(cols, i)
+		}
+	}
+	display := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+
+	tw := new(tabwriter.Writer).Init(w, 0, 8, 2, ' ', 0)
+	if t.vertical {
+		for n, row := range t.rows {
+			if n > 0 {
+				fmt.Fprintln(tw)
+			}
+			for _, i := range cols {
+				fmt.Fprintf(tw, "%s:\t%s\t\n", t.headers[i], display(row[i]))
+			}
+		}
+		return tw.Flush()
+	}
+
+	line := make([]string, len(cols))
+	for n, i := range cols {
+		line[n] = t.headers[i]
+	}
+	fmt.Fprintln(tw, strings.Join(line, "\t"))
+	for n, i := range cols {
+		line[n] = strings.Repeat("-", len(t.headers[i]))
+	}
+	fmt.Fprintln(tw, strings.Join(line, "\t"))
+	for _, row := range t.rows {
+		for n, i := range cols {
+			line[n] = display(row[i])
+		}
+		fmt.Fprintln(tw, strings.Join(line, "\t"))
+	}
+	return tw.Flush()
+}
+
+func (t *Table) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write(t.headers)
+	for _, row := range t.rows {
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
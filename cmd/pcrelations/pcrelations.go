@@ -1,17 +1,18 @@
 package pcrelations
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPCRelations returns new initialized instance of assocs sub command
-func NewCmdPCRelations() *cobra.Command {
+func NewCmdPCRelations(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "product-category-relations",
 		Short: "product to category relations",
 	}
-	cmd.AddCommand(NewCmdPCRelationsApply())
-	cmd.AddCommand(NewCmdPCRelationsList())
-	cmd.AddCommand(NewCmdPCRelationsDelete())
+	cmd.AddCommand(NewCmdPCRelationsApply(f))
+	cmd.AddCommand(NewCmdPCRelationsList(f))
+	cmd.AddCommand(NewCmdPCRelationsDelete(f))
 	return cmd
 }
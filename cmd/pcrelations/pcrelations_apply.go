@@ -1,59 +1,52 @@
 package pcrelations
 
 import (
-	"context"
 	"fmt"
 	"io/ioutil"
-	"os"
 
 	"gopkg.in/yaml.v2"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPCRelationsApply returns new initialized instance of apply sub command
-func NewCmdPCRelationsApply() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPCRelationsApply(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "apply <product-category-relations.yaml>",
 		Short: "Replace all product to category relations",
 		Long:  ``,
 
 		Args: cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			err := client.SetToken(&current)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := ioutil.ReadFile(args[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+			if err := cmdvalidate.ProductCategoryRelations(args[0], data); err != nil {
+				return err
 			}
 
-			data, err := ioutil.ReadFile(args[0])
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			var relationships eclient.ProductCategoryRelationsYAML
 			err = yaml.Unmarshal([]byte(data), &relationships)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			// retrieve a list of all products and build a map
 			// of sku -> product ids
-			products, err := client.GetProducts(context.TODO())
+			products, err := client.GetProducts(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "failed to get products: %+v", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to get products: %w", err)
 			}
 			productSKUToID := make(map[string]string)
 			for _, p := range products {
@@ -64,10 +57,9 @@ func NewCmdPCRelationsApply() *cobra.Command {
 
 			// retrieve a list of all categories and build a map
 			// of path -> category ids
-			categories, err := client.GetCategories()
+			categories, err := client.GetCategories(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "failed to get categories: %+v", err)
-				os.Exit(1)
+				return fmt.Errorf("failed to get categories: %w", err)
 			}
 			categoryPathToID := make(map[string]string)
 			for _, c := range categories {
@@ -76,12 +68,12 @@ func NewCmdPCRelationsApply() *cobra.Command {
 
 			for path, productset := range relationships.Rels {
 				if _, ok := categoryPathToID[path]; !ok {
-					fmt.Fprintf(os.Stderr, "Category path %s not found.\n", path)
+					fmt.Fprintf(f.IOStreams.ErrOut, "Category path %s not found.\n", path)
 				}
 
 				for _, sku := range productset.Products {
 					if _, ok := productSKUToID[sku]; !ok {
-						fmt.Fprintf(os.Stderr, "Product SKU=%s in Category path=%s section not found.\n", sku, path)
+						fmt.Fprintf(f.IOStreams.ErrOut, "Product SKU=%s in Category path=%s section not found.\n", sku, path)
 					}
 				}
 			}
@@ -97,12 +89,16 @@ func NewCmdPCRelationsApply() *cobra.Command {
 				}
 			}
 
-			err = client.UpdateProductCategoryRelations(rels)
+			if err := f.Confirm(yes, "replace all product to category relations with the %d in %s", len(rels), args[0]); err != nil {
+				return err
+			}
+			err = client.UpdateProductCategoryRelations(ctx, rels)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
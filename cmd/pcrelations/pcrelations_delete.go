@@ -1,36 +1,35 @@
 package pcrelations
 
 import (
-	"fmt"
-	"os"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPCRelationsDelete returns new initialized instance of delete sub command
-func NewCmdPCRelationsDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPCRelationsDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete",
 		Short: "Delete all product to category relations",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+			rels, err := client.GetProductCategoryRelations(ctx)
+			if err != nil {
+				return err
+			}
+			if err := f.Confirm(yes, "delete all %d product to category relations", len(rels)); err != nil {
+				return err
 			}
-			if err = client.DeleteProductCategoryRelations(); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+			if err = client.DeleteProductCategoryRelations(ctx); err != nil {
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
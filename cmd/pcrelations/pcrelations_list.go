@@ -2,36 +2,42 @@ package pcrelations
 
 import (
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPCRelationsList returns new initialized instance of list sub command
-func NewCmdPCRelationsList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdPCRelationsList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "List all product to category relations",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			pcrelations, err := client.GetProductCategoryRelations()
+			pcrelations, err := client.GetProductCategoryRelations(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+
+			if !out.Human() {
+				t := output.NewTable("Category Path", "Product SKU", "Pri").Wide(
+					"Relation ID", "Category ID", "Product ID", "Product Path",
+					"Created", "Modified")
+				for _, v := range pcrelations {
+					t.Row(v.CategoryPath, v.ProductSKU, v.Pri, v.ID, v.CategoryID,
+						v.ProductID, v.ProductPath, v.Created, v.Modified)
+				}
+				if err := out.Print(f.IOStreams.Out, pcrelations, t); err != nil {
+					return err
+				}
+				return nil
 			}
 
 			categoryPathToProductList := make(map[string][]string)
@@ -41,16 +47,17 @@ func NewCmdPCRelationsList() *cobra.Command {
 			}
 
 			// Display the associations in order
-			fmt.Println("product_category_relations:")
+			fmt.Fprintln(f.IOStreams.Out, "product_category_relations:")
 			for categoryPath := range categoryPathToProductList {
-				fmt.Printf("  %s:\n", categoryPath)
-				fmt.Println("    products:")
+				fmt.Fprintf(f.IOStreams.Out, "  %s:\n", categoryPath)
+				fmt.Fprintln(f.IOStreams.Out, "    products:")
 				for _, sku := range categoryPathToProductList[categoryPath] {
-					fmt.Printf("      - %s\n", sku)
+					fmt.Fprintf(f.IOStreams.Out, "      - %s\n", sku)
 				}
 			}
-			os.Exit(0)
+			return nil
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
@@ -1,18 +1,19 @@
 package ppagroups
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPPAGroups returns new initialized instance of the ppagroups sub command
-func NewCmdPPAGroups() *cobra.Command {
+func NewCmdPPAGroups(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "ppagroups",
 		Short: "Product to product associations groups Management",
 	}
-	cmd.AddCommand(NewCmdPPAGroupCreate())
-	cmd.AddCommand(NewCmdPPAGroupsList())
-	cmd.AddCommand(NewCmdPPAGroupsGet())
-	cmd.AddCommand(NewCmdPPAGroupsDelete())
+	cmd.AddCommand(NewCmdPPAGroupCreate(f))
+	cmd.AddCommand(NewCmdPPAGroupsList(f))
+	cmd.AddCommand(NewCmdPPAGroupsGet(f))
+	cmd.AddCommand(NewCmdPPAGroupsDelete(f))
 	return cmd
 }
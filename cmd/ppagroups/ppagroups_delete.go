@@ -1,41 +1,33 @@
 package ppagroups
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPPAGroupsDelete returns new initialized instance of the delete sub command
-func NewCmdPPAGroupsDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPPAGroupsDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <code>",
 		Short: "Delete a product to product associations group by code",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// code
 			code := args[0]
-			ctx := context.Background()
 			groups, err := client.GetPPAGroups(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var ppaGroupID string
 			for _, g := range groups {
@@ -45,25 +37,25 @@ func NewCmdPPAGroupsDelete() *cobra.Command {
 				}
 			}
 			if ppaGroupID == "" {
-				fmt.Fprintf(os.Stderr,
-					"product to product associations group code %q not found\n", code)
-				os.Exit(1)
+				return fmt.Errorf("product to product associations group code %q not found", code)
 			}
 
+			if err := f.Confirm(yes, "delete product to product associations group %q (%s)", code, ppaGroupID); err != nil {
+				return err
+			}
 			err = client.DeletePPAGroup(ctx, ppaGroupID)
-			if err == eclient.ErrBadRequest {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrBadRequest) {
+				return err
 			}
-			if err == eclient.ErrPPAssocGroupNotFound {
-				fmt.Fprintf(os.Stderr, "product to product associations group not found. Use ecom ppagroups list to check.\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPPAssocGroupNotFound) {
+				return fmt.Errorf("product to product associations group not found. Use ecom ppagroups list to check.")
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
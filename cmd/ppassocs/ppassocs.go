@@ -1,16 +1,17 @@
 package ppassocs
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPPAssocs returns new initialized instance of the ppassocs sub command
-func NewCmdPPAssocs() *cobra.Command {
+func NewCmdPPAssocs(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "ppassocs",
 		Short: "Product to product associations management",
 	}
-	cmd.AddCommand(NewCmdPPAssocsList())
-	cmd.AddCommand(NewCmdPPAssocsDelete())
+	cmd.AddCommand(NewCmdPPAssocsList(f))
+	cmd.AddCommand(NewCmdPPAssocsDelete(f))
 	return cmd
 }
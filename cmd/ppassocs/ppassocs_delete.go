@@ -1,57 +1,51 @@
 package ppassocs
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPPAssocsDelete returns new initialized instance of the delete sub command
-func NewCmdPPAssocsDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPPAssocsDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <pp_assocs_id>",
 		Short: "Delete a product to product associations",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// code
 			ppAssocID := args[0]
 			if !cmdvalidate.IsValidUUID(ppAssocID) {
-				fmt.Fprintf(os.Stderr, "pp_assocs_id must be a valid v4 uuid\n")
-				os.Exit(1)
+				return fmt.Errorf("pp_assocs_id must be a valid v4 uuid")
 			}
 
-			ctx := context.Background()
+			if err := f.Confirm(yes, "delete product to product associations %s", ppAssocID); err != nil {
+				return err
+			}
 			err = client.DeletePPAssoc(ctx, ppAssocID)
-			if err == eclient.ErrBadRequest {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrBadRequest) {
+				return err
 			}
-			if err == eclient.ErrPPAssocNotFound {
-				fmt.Fprintf(os.Stderr, "product to product associations not found. Use ecom ppassocs list to check.\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPPAssocNotFound) {
+				return fmt.Errorf("product to product associations not found. Use ecom ppassocs list to check.")
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
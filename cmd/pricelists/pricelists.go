@@ -1,19 +1,20 @@
 package pricelists
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPriceLists returns new initialized instance of pricelist sub command
-func NewCmdPriceLists() *cobra.Command {
+func NewCmdPriceLists(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "pricelists",
 		Short: "Price list management",
 	}
-	cmd.AddCommand(NewCmdPriceListsCreate())
-	cmd.AddCommand(NewCmdPriceListsGet())
-	cmd.AddCommand(NewCmdPriceListsList())
-	cmd.AddCommand(NewCmdPriceListUpdate())
-	cmd.AddCommand(NewCmdPriceListsDelete())
+	cmd.AddCommand(NewCmdPriceListsCreate(f))
+	cmd.AddCommand(NewCmdPriceListsGet(f))
+	cmd.AddCommand(NewCmdPriceListsList(f))
+	cmd.AddCommand(NewCmdPriceListUpdate(f))
+	cmd.AddCommand(NewCmdPriceListsDelete(f))
 	return cmd
 }
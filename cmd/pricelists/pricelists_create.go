@@ -1,51 +1,40 @@
 package pricelists
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
+	"io"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
-	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdPriceListsCreate returns new initialized instance of create sub command
-func NewCmdPriceListsCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPriceListsCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a price list",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			req, err := promptCreatePriceList()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			// attempt to create the price list
-			ctx := context.Background()
 			priceList, err := client.CreatePriceList(ctx, req)
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			showPriceList(priceList)
+			showPriceList(f.IOStreams.Out, priceList)
+			return nil
 		},
 	}
 	return cmd
@@ -104,17 +93,14 @@ func promptCreatePriceList() (*eclient.CreatePriceListRequest, error) {
 	return &req, nil
 }
 
-func showPriceList(v *eclient.PriceList) {
-	format := "%v\t%v\t\n"
-	tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, format, "Price List ID:", v.ID)
-	fmt.Fprintf(tw, format, "Price List Code:", v.PriceListCode)
-	fmt.Fprintf(tw, format, "Currency Code:", v.CurrencyCode)
-	fmt.Fprintf(tw, format, "Strategy:", v.Strategy)
-	fmt.Fprintf(tw, format, "Inc Tax:", v.IncTax)
-	fmt.Fprintf(tw, format, "Name:", v.Name)
-	fmt.Fprintf(tw, format, "Description:", v.Description)
-	fmt.Fprintf(tw, format, "Created:", v.Created.In(service.Location).Format(service.TimeDisplayFormat))
-	fmt.Fprintf(tw, format, "Modified:", v.Modified.In(service.Location).Format(service.TimeDisplayFormat))
-	tw.Flush()
+func showPriceList(w io.Writer, v *eclient.PriceList) {
+	priceListRecord(v).Write(w)
+}
+
+func priceListRecord(v *eclient.PriceList) *output.Table {
+	t := output.NewRecord("Price List ID", "Price List Code", "Currency Code",
+		"Strategy", "Inc Tax", "Name", "Description", "Created", "Modified")
+	t.Row(v.ID, v.PriceListCode, v.CurrencyCode, v.Strategy, v.IncTax,
+		v.Name, v.Description, v.Created, v.Modified)
+	return t
 }
@@ -1,40 +1,30 @@
 package pricelists
 
 import (
-	"context"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPriceListsDelete returns new initialized instance of the delete sub command
-func NewCmdPriceListsDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPriceListsDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <price_list_code>",
 		Short: "Delete price list",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			priceListCode := args[0]
-			ctx := context.Background()
 			priceLists, err := client.GetPriceLists(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var priceListID string
 			for _, v := range priceLists {
@@ -44,18 +34,19 @@ func NewCmdPriceListsDelete() *cobra.Command {
 				}
 			}
 			if priceListID == "" {
-				fmt.Fprintf(os.Stderr,
-					"price list with code %q not found\n",
-					priceListCode)
-				os.Exit(1)
+				return fmt.Errorf("price list with code %q not found", priceListCode)
 			}
 
+			if err := f.Confirm(yes, "delete price list %q (%s)", priceListCode, priceListID); err != nil {
+				return err
+			}
 			err = client.DeletePriceList(ctx, priceListID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
@@ -1,40 +1,41 @@
 package pricelists
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPriceListsGet returns new initialized instance of the get sub command
-func NewCmdPriceListsGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPriceListsGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
-		Use:   "get <price_list_code>",
+		Use:   "get [price_list_code]",
 		Short: "Get price list",
-		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		Long: `Get a price list. Without a price_list_code the price-list preference
+of the profile is used.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			priceListCode := args[0]
-			ctx := context.Background()
+			priceListCode := f.PriceListCode()
+			if len(args) > 0 {
+				priceListCode = args[0]
+			}
+			if priceListCode == "" {
+				return fmt.Errorf("no price list; give a price_list_code or use ecom profiles set <name> price-list <code>")
+			}
 			priceLists, err := client.GetPriceLists(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var priceListID string
 			for _, v := range priceLists {
@@ -45,17 +46,17 @@ func NewCmdPriceListsGet() *cobra.Command {
 			}
 
 			priceList, err := client.GetPriceList(ctx, priceListID)
-			if err == eclient.ErrProductNotFound {
-				fmt.Printf("price list %s not found.\n", priceListCode)
-				os.Exit(0)
+			if errors.Is(err, eclient.ErrPriceListNotFound) {
+				fmt.Fprintf(f.IOStreams.Out, "price list %s not found.\n", priceListCode)
+				return nil
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			showPriceList(priceList)
+			return out.Print(f.IOStreams.Out, priceList, priceListRecord(priceList))
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
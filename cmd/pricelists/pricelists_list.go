@@ -1,68 +1,39 @@
 package pricelists
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPriceListsList returns new initialized instance of the get sub command
-func NewCmdPriceListsList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPriceListsList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "list price lists",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
 			priceLists, err := client.GetPriceLists(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%v\t%v\t%v\t%v\t%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format,
-				"Price List Code",
-				"Currency Code",
-				"Strategy",
-				"Inc Tax",
-				"Name",
-				"Description")
-			fmt.Fprintf(tw, format,
-				"---------------",
-				"-------------",
-				"--------",
-				"-------",
-				"----",
-				"-----------")
+			t := output.NewTable("Price List Code", "Currency Code", "Strategy",
+				"Inc Tax", "Name", "Description").Wide("Price List ID",
+				"Created", "Modified")
 			for _, v := range priceLists {
-				fmt.Fprintf(tw, format,
-					v.PriceListCode,
-					v.CurrencyCode,
-					v.Strategy,
-					v.IncTax,
-					v.Name,
-					v.Description)
+				t.Row(v.PriceListCode, v.CurrencyCode, v.Strategy, v.IncTax,
+					v.Name, v.Description, v.ID, v.Created, v.Modified)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, priceLists, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
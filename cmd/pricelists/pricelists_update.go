@@ -1,42 +1,32 @@
 package pricelists
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdPriceListUpdate returns new initialized instance of the update sub command
-func NewCmdPriceListUpdate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPriceListUpdate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "update <price_list_code>",
 		Short: "Update a price list",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			priceListCode := args[0]
-			ctx := context.Background()
 			priceLists, err := client.GetPriceLists(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var priceListID string
 			for _, v := range priceLists {
@@ -46,50 +36,35 @@ func NewCmdPriceListUpdate() *cobra.Command {
 				}
 			}
 			if priceListID == "" {
-				fmt.Fprintf(os.Stderr,
-					"price list with code %q not found\n",
-					priceListCode)
-				os.Exit(1)
+				return fmt.Errorf("price list with code %q not found", priceListCode)
 			}
 
 			existing, err := client.GetPriceList(ctx, priceListID)
-			if err == eclient.ErrPriceListNotFound {
-				fmt.Fprintf(os.Stderr,
-					"price list %s not found\n",
-					priceListCode)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPriceListNotFound) {
+				return fmt.Errorf("price list %s not found", priceListCode)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			req, err := promptUpdatePriceList(existing)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			priceList, err := client.UpdatePriceList(ctx, priceListID, req)
-			if err == eclient.ErrPriceListNotFound {
-				fmt.Fprintf(os.Stderr,
-					"price list %s not found\n",
-					priceListCode)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPriceListNotFound) {
+				return fmt.Errorf("price list %s not found", priceListCode)
 			}
-			if err == eclient.ErrPriceListCodeExists {
-				fmt.Fprintf(os.Stderr,
-					"price list %s is already exists\n",
-					priceListCode)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPriceListCodeExists) {
+				return fmt.Errorf("price list %s is already exists", priceListCode)
 			}
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				fmt.Fprintf(os.Stderr, "error creating user: %+v\n", errors.Unwrap(err))
-				os.Exit(1)
+				return fmt.Errorf("error updating price list: %w", err)
 			}
 
-			showPriceList(priceList)
+			showPriceList(f.IOStreams.Out, priceList)
+			return nil
 		},
 	}
 	return cmd
@@ -1,15 +1,16 @@
 package prices
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPrices returns new initialized instance of prices sub command
-func NewCmdPrices() *cobra.Command {
+func NewCmdPrices(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "prices",
 		Short: "Prices Management",
 	}
-	cmd.AddCommand(NewCmdPricesList())
+	cmd.AddCommand(NewCmdPricesList(f))
 	return cmd
 }
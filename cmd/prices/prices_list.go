@@ -1,77 +1,77 @@
 package prices
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v", "Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdPricesList returns new initialized instance of the list sub command
-func NewCmdPricesList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdPricesList(f *cmdutil.Factory) *cobra.Command {
+	var opts eclient.ListOptions
+	var out output.Printer
+	var priceListCode string
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "list all prices for all products",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		Long: `List the prices of all products. If the profile has a price-list
+preference only the prices in that price list are listed; use
+--price-list "" to list the prices in every price list.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+			if !cmd.Flags().Changed("price-list") {
+				priceListCode = f.PriceListCode()
 			}
 
-			ctx := context.Background()
-			prices, err := client.GetPrices(ctx)
+			priceLists, err := client.GetPriceLists(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+			currency := make(map[string]string, len(priceLists))
+			for _, v := range priceLists {
+				currency[v.PriceListCode] = v.CurrencyCode
 			}
 
-			format := "%s\t%s\t%s\t%v\t£%.4f\t%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
-				"Price ID", "Product SKU",
-				"Price List Code", "Break", "Unit Price",
-				"Created", "Modified")
-			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
-				"--------", "-----------",
-				"---------------", "-----", "----------",
-				"-------", "--------")
+			// filter as the prices arrive, so --limit counts only the
+			// prices listed
+			iterOpts := opts
+			iterOpts.Limit = 0
+			it := client.Prices(&iterOpts)
+			prices := make([]*eclient.Price, 0, 16)
+			for opts.Limit == 0 || len(prices) < opts.Limit {
+				p, err := it.Next(ctx)
+				if err == eclient.ErrDone {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				if priceListCode != "" && p.PriceListCode != priceListCode {
+					continue
+				}
+				prices = append(prices, p)
+			}
 
+			t := output.NewTable("Price ID", "Product SKU", "Price List Code",
+				"Break", "Unit Price", "Created", "Modified").Wide("Product ID",
+				"Price List ID")
 			for _, p := range prices {
-				fmt.Fprintf(tw, format,
-					p.ID, p.ProductSKU,
-					p.PriceListCode, p.Break,
-					float64(p.UnitPrice)/10000.0,
-					p.Created.In(location).Format(timeDisplayFormat),
-					p.Modified.In(location).Format(timeDisplayFormat))
+				t.Row(p.ID, p.ProductSKU, p.PriceListCode, p.Break,
+					display.Price(p.UnitPrice, currency[p.PriceListCode]),
+					p.Created, p.Modified, p.ProductID, p.PriceListID)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, prices, t)
 		},
 	}
+	cmdutil.AddListFlags(cmd, &opts)
+	cmd.Flags().StringVar(&priceListCode, "price-list", "",
+		"list only the prices in this price list (default from the price-list preference)")
+	output.AddFlag(cmd, &out)
 	return cmd
 }
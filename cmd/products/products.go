@@ -1,18 +1,20 @@
 package products
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdProducts returns new initialized instance of products sub command
-func NewCmdProducts() *cobra.Command {
+func NewCmdProducts(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "products",
 		Short: "Products management",
 	}
-	cmd.AddCommand(NewCmdProductsApply())
-	cmd.AddCommand(NewCmdProductsDelete())
-	cmd.AddCommand(NewCmdProductsGet())
-	cmd.AddCommand(NewCmdProductsList())
+	cmd.AddCommand(NewCmdProductsApply(f))
+	cmd.AddCommand(NewCmdProductsDelete(f))
+	cmd.AddCommand(NewCmdProductsExport(f))
+	cmd.AddCommand(NewCmdProductsGet(f))
+	cmd.AddCommand(NewCmdProductsList(f))
 	return cmd
 }
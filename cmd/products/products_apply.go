@@ -2,178 +2,313 @@ package products
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
-	"path/filepath"
+	"path"
+	"sync"
 
-	"gopkg.in/yaml.v2"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdProductsApply returns new initialized instance of the apply sub command
-func NewCmdProductsApply() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdProductsApply(f *cmdutil.Factory) *cobra.Command {
+	var dryRun, prune, yes bool
+	var concurrency int
+	var exclude []string
 	var cmd = &cobra.Command{
 		Use:   "apply <product.yaml>|<dir>",
 		Short: "Create or update an exising product",
-		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
-			}
-
-			// load all price lists
-			ctx := context.Background()
-			priceLists, err := client.GetPriceLists(ctx)
+		Long: `Create or update the product in a YAML file, or in each YAML file in a
+directory. Each file is compared with the live product, its images and
+its prices, and a plan is printed: products to create, products to
+update with the fields that change, unchanged products and files that
+are skipped. Only the changes are then sent; use --dry-run to print the
+plan without applying it. On a protected profile the changes must be
+confirmed as for ecom products delete. Every file is checked first, as by ecom
+validate, and nothing is applied if any is invalid.
+
+With --concurrency N, up to N files are planned and applied at once.
+Failures do not stop the other files; a summary of the products
+created, updated, skipped and failed, with the reasons, is printed at
+the end.
+
+With --prune, the directory holds the whole catalog: once every file
+has been applied, live products whose SKUs are in none of the files are
+deleted. They are listed in the plan and you are asked to confirm
+before anything is changed; use --yes when stdin is not a terminal.
+Nothing is pruned if any file fails. Products matching an --exclude glob, for example --exclude
+'GIFT-*', are never pruned.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if concurrency < 1 {
+				return fmt.Errorf("--concurrency must be at least 1")
+			}
+			for _, pattern := range exclude {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("--exclude %q: %w", pattern, err)
+				}
+			}
+
+			isDir, err := isDirectory(args[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+			files := []string{args[0]}
+			if isDir {
+				if files, err = cmdutil.YAMLFiles(args[0]); err != nil {
+					return err
+				}
+			}
+			if prune && !isDir {
+				return fmt.Errorf("--prune needs a directory of product files")
+			}
+			if prune && len(files) == 0 {
+				return fmt.Errorf("no product files in %s; refusing to prune every product", args[0])
+			}
+
+			// check every file before making any API call
+			invalid := 0
+			for _, file := range files {
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				if err := cmdvalidate.Product(file, data); err != nil {
+					fmt.Fprintln(f.IOStreams.ErrOut, err)
+					invalid++
+				}
+			}
+			if invalid > 0 {
+				return fmt.Errorf("%d of %d files are invalid; nothing was applied", invalid, len(files))
 			}
 
-			// load all products
-			products, err := client.GetProducts(context.TODO())
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			isDir, err := isDirectory(args[0])
+			// load all products, price lists and prices
+			c, err := loadCatalog(ctx, client)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
+			}
+
+			// plan the files in parallel, as planning an existing product
+			// fetches its images
+			plans := make([]*productPlan, len(files))
+			progress := cmdutil.NewProgress(f.IOStreams.ErrOut, "Planning", len(files))
+			forEach(ctx, concurrency, len(files), func(i int) {
+				p, err := planProduct(ctx, client, c, files[i])
+				if err != nil {
+					p = &productPlan{file: files[i], action: actionFailed, err: err}
+				}
+				plans[i] = p
+				progress.Add(1)
+			})
+			progress.Finish()
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			count := make(map[string]int)
+			seen := make(map[string]string) // sku -> file
+			for _, p := range plans {
+				if other, ok := seen[p.sku]; ok && p.action != actionSkip && p.action != actionFailed {
+					p.action, p.reason = actionSkip, fmt.Sprintf("sku %s is also in %s", p.sku, other)
+				}
+				if p.action != actionSkip && p.action != actionFailed {
+					seen[p.sku] = p.file
+				}
+				p.print(f.IOStreams.Out)
+				count[p.action]++
 			}
+			var deletes []*productPlan
+			if prune {
+				if count[actionFailed] > 0 {
+					fmt.Fprintf(f.IOStreams.Out, "Not pruning, as %d files could not be planned.\n", count[actionFailed])
+				} else {
+					deletes = planPrune(c, plans, exclude)
+				}
+				for _, p := range deletes {
+					p.print(f.IOStreams.Out)
+				}
+			}
+			fmt.Fprintf(f.IOStreams.Out, "Plan: %d to create, %d to update, %d to delete, %d unchanged, %d skipped, %d failed.\n",
+				count[actionCreate], count[actionUpdate], len(deletes), count[actionUnchanged], count[actionSkip], count[actionFailed])
 			if !isDir {
-				if err := applyProduct(client, products, priceLists, args[0]); err != nil {
-					if err == errMissingEAN {
-						fmt.Fprintf(os.Stderr, "Skipping %s as EAN is missing\n", args[0])
-						os.Exit(1)
-					}
-					fmt.Fprintf(os.Stderr, "%+v\n", err)
-					os.Exit(1)
+				switch plans[0].action {
+				case actionSkip:
+					return fmt.Errorf("skipping %s: %s", plans[0].file, plans[0].reason)
+				case actionFailed:
+					return plans[0].err
+				}
+			}
+			if dryRun {
+				if count[actionFailed] > 0 {
+					return fmt.Errorf("%d of %d files could not be planned", count[actionFailed], len(plans))
+				}
+				return nil
+			}
+			if len(deletes) > 0 {
+				if err := f.Ask(yes, fmt.Sprintf("Delete %d products?", len(deletes))); err != nil {
+					return err
+				}
+			}
+			if count[actionCreate]+count[actionUpdate]+len(deletes) > 0 {
+				if err := f.Confirm(yes, "create %d, update %d and delete %d products from %s",
+					count[actionCreate], count[actionUpdate], len(deletes), args[0]); err != nil {
+					return err
 				}
-				os.Exit(0)
 			}
 
-			matches, err := filepath.Glob(args[0] + "/*.yaml")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+			// Each product is applied using a context that is not cancelled
+			// by an interrupt or the --timeout deadline, so a product is
+			// never left half-applied. Cancellation is checked between files.
+			todo := make([]*productPlan, 0, count[actionCreate]+count[actionUpdate])
+			for _, p := range plans {
+				if p.action == actionCreate || p.action == actionUpdate {
+					todo = append(todo, p)
+				}
+			}
+			progress = cmdutil.NewProgress(f.IOStreams.ErrOut, "Applying", len(todo))
+			forEach(ctx, concurrency, len(todo), func(i int) {
+				p := todo[i]
+				if err := p.apply(context.Background(), client); err != nil {
+					p.err = err
+				} else {
+					p.applied = true
+				}
+				progress.Add(1)
+			})
+			progress.Finish()
+
+			// prune only once everything else has been applied, so a
+			// product is never deleted because its file failed
+			if len(deletes) > 0 && ctx.Err() == nil {
+				if n := countFailed(todo); n > 0 {
+					fmt.Fprintf(f.IOStreams.Out, "Not pruning, as %d products failed.\n", n)
+				} else {
+					progress = cmdutil.NewProgress(f.IOStreams.ErrOut, "Pruning", len(deletes))
+					forEach(ctx, concurrency, len(deletes), func(i int) {
+						p := deletes[i]
+						if err := p.apply(context.Background(), client); err != nil {
+							p.err = err
+						} else {
+							p.applied = true
+						}
+						progress.Add(1)
+					})
+					progress.Finish()
+				}
 			}
 
-			for _, file := range matches {
-				if err := applyProduct(client, products, priceLists, file); err != nil {
-					if err == errMissingEAN {
-						fmt.Fprintf(os.Stderr, "Skipping %s as EAN is missing\n", file)
-						continue
+			failed := printSummary(f.IOStreams.Out, append(plans, deletes...))
+			if err := ctx.Err(); err != nil {
+				applied := 0
+				for _, p := range append(todo, deletes...) {
+					if p.applied {
+						applied++
 					}
-					fmt.Fprintf(os.Stderr, "%+v\n", err)
-					os.Exit(1)
 				}
+				return fmt.Errorf("stopped after applying %d of %d changed products: %w",
+					applied, len(todo)+len(deletes), err)
 			}
-			os.Exit(0)
+			if failed > 0 {
+				if !isDir {
+					return plans[0].err
+				}
+				return fmt.Errorf("%d of %d products failed", failed, len(plans)+len(deletes))
+			}
+			return nil
 		},
 	}
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the plan without applying it")
+	cmd.Flags().IntVar(&concurrency, "concurrency", 1, "number of files to plan and apply at once")
+	cmd.Flags().BoolVar(&prune, "prune", false, "delete live products that have no file in the directory")
+	cmd.Flags().StringSliceVar(&exclude, "exclude", nil, "SKU glob of products never to prune (repeatable)")
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
 
-var errMissingEAN = errors.New("missing EAN")
-
-func findProductBySKU(products []*eclient.ProductResponse, sku string) *eclient.ProductResponse {
-	for _, p := range products {
-		if sku == p.SKU {
-			return p
-		}
-	}
-	return nil
-}
-
-func applyProduct(ec *eclient.EcomClient, products []*eclient.ProductResponse, priceLists []*eclient.PriceList, filename string) error {
-	// create a map of priceListCode -> priceListID
-	priceListCodeToID := make(map[string]string)
-	for _, p := range priceLists {
-		priceListCodeToID[p.PriceListCode] = p.ID
-	}
-
-	file, err := os.Open(filename)
+func isDirectory(path string) (bool, error) {
+	fileInfo, err := os.Stat(path)
 	if err != nil {
-		return fmt.Errorf("os.Open(%q) failed: %w", filename, err)
-	}
-	defer file.Close()
-
-	container := eclient.ProductContainerYAML{}
-	dec := yaml.NewDecoder(file)
-	if err := dec.Decode(&container); err != nil {
-		return err
+		return false, fmt.Errorf("os.Stat(%q) failed: %w", path, err)
 	}
+	return fileInfo.IsDir(), err
+}
 
-	product := findProductBySKU(products, container.Product.SKU)
-	request := eclient.ProductRequest{
-		Path: container.Product.Path,
-		SKU:  container.Product.SKU,
-		Name: container.Product.Name,
+// forEach calls fn with each index from 0 to n-1, from up to workers
+// goroutines at once. It stops starting calls once ctx is done.
+func forEach(ctx context.Context, workers, n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < n; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
 	}
-
-	if product != nil {
-		_, err = ec.ReplaceProduct(product.ID, &request)
-		if err != nil {
-			return err
-		}
-
-		if err := ec.DeleteProductImages(product.ID); err != nil {
-			fmt.Fprintf(os.Stderr, "failed to delete images for product sku=%s\n", product.SKU)
-			os.Exit(1)
-		}
-	} else {
-		product, err = ec.CreateProduct(&request)
-		if err != nil {
-			return err
+loop:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break loop
 		}
 	}
+	close(jobs)
+	wg.Wait()
+}
 
-	for _, i := range container.Product.Images {
-		ir := eclient.ImageRequest{
-			ProductID: product.ID,
-			Path:      i.Path,
-		}
-		_, err := ec.CreateImage(ir)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "create image failed: %v", err)
-			os.Exit(1)
+// countFailed returns the number of plans that failed to apply.
+func countFailed(plans []*productPlan) int {
+	n := 0
+	for _, p := range plans {
+		if p.err != nil {
+			n++
 		}
 	}
+	return n
+}
 
-	// set prices
-	newPrices := make([]*eclient.PriceRequest, 0)
-	for priceListCode, prices := range container.Product.Prices {
-		for _, price := range prices {
-			pr := eclient.PriceRequest{
-				Break:     price.Break,
-				UnitPrice: price.UnitPrice,
-			}
-			newPrices = append(newPrices, &pr)
+// printSummary writes the outcome of applying the plans, listing the
+// files skipped and failed with the reasons. It returns the number of
+// failures.
+func printSummary(w io.Writer, plans []*productPlan) int {
+	var created, updated, deleted, unchanged int
+	var skipped, failed []*productPlan
+	for _, p := range plans {
+		switch {
+		case p.err != nil:
+			failed = append(failed, p)
+		case p.action == actionSkip:
+			skipped = append(skipped, p)
+		case p.action == actionUnchanged:
+			unchanged++
+		case p.action == actionCreate && p.applied:
+			created++
+		case p.action == actionUpdate && p.applied:
+			updated++
+		case p.action == actionDelete && p.applied:
+			deleted++
 		}
-		ec.SetPrices(product.ID, priceListCodeToID[priceListCode], newPrices)
 	}
-
-	return nil
-}
-
-func isDirectory(path string) (bool, error) {
-	fileInfo, err := os.Stat(path)
-	if err != nil {
-		return false, fmt.Errorf("os.Stat(%q) failed: %w", path, err)
+	fmt.Fprintf(w, "Summary: %d created, %d updated, %d deleted, %d unchanged, %d skipped, %d failed.\n",
+		created, updated, deleted, unchanged, len(skipped), len(failed))
+	for _, p := range skipped {
+		fmt.Fprintf(w, "  skipped %s: %s\n", p.file, p.reason)
 	}
-	return fileInfo.IsDir(), err
+	for _, p := range failed {
+		fmt.Fprintf(w, "  failed %s: %v\n", p.name(), p.err)
+	}
+	return len(failed)
 }
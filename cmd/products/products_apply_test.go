@@ -0,0 +1,146 @@
+package products
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclienttest"
+)
+
+func TestForEach(t *testing.T) {
+	for _, workers := range []int{1, 3, 10} {
+		var mu sync.Mutex
+		calls := make(map[int]int)
+		running, maxRunning := 0, 0
+		forEach(context.Background(), workers, 7, func(i int) {
+			mu.Lock()
+			calls[i]++
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+			mu.Unlock()
+			time.Sleep(time.Millisecond)
+			mu.Lock()
+			running--
+			mu.Unlock()
+		})
+		for i := 0; i < 7; i++ {
+			if calls[i] != 1 {
+				t.Errorf("workers=%d: fn(%d) called %d times, want once", workers, i, calls[i])
+			}
+		}
+		limit := workers
+		if limit > 7 {
+			limit = 7
+		}
+		if maxRunning > limit {
+			t.Errorf("workers=%d: %d calls at once, want at most %d", workers, maxRunning, limit)
+		}
+	}
+}
+
+func TestForEachCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	var calls []int
+	forEach(ctx, 1, 100, func(i int) {
+		mu.Lock()
+		calls = append(calls, i)
+		mu.Unlock()
+		if i == 2 {
+			cancel()
+		}
+	})
+	// the call in flight finishes and at most one more may already
+	// have been handed to the worker
+	if len(calls) < 3 || len(calls) > 4 {
+		t.Fatalf("got calls %v after cancelling in the third, want 3 or 4", calls)
+	}
+	for i, n := range calls {
+		if n != i {
+			t.Errorf("calls are %v, want them in order", calls)
+			break
+		}
+	}
+}
+
+// failSKU is a RoundTripper that fails the creation of the products
+// with the given SKU.
+type failSKU string
+
+func (sku failSKU) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/products") && req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Contains(body, []byte(`"sku":"`+string(sku)+`"`)) {
+			return nil, errors.New("connection reset")
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestProductsApplySummary(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+	createProduct(t, srv, "B")
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":   productFile("A", "Product A"),
+		"b.yaml":   productFile("B", "Bee"),
+		"bad.yaml": productFile("BAD", "Bad"),
+		"c.yaml":   productFile("C", "Product C"),
+		"d.yaml":   productFile("D", "Product D"),
+		"dup.yaml": productFile("D", "Another D"),
+	})
+	defer os.RemoveAll(dir)
+
+	c := newTestCmd(srv)
+	c.opts = []eclient.Option{eclient.WithTransport(failSKU("BAD"))}
+	err := c.run("apply", dir, "--concurrency", "3")
+	if err == nil || err.Error() != "1 of 6 products failed" {
+		t.Fatalf("products apply = %v, want 1 of 6 products failed", err)
+	}
+	out := c.out.String()
+	for _, want := range []string{
+		"Summary: 2 created, 1 updated, 0 deleted, 1 unchanged, 1 skipped, 1 failed.\n",
+		"  skipped " + filepath.Join(dir, "dup.yaml") + ": sku D is also in " + filepath.Join(dir, "d.yaml") + "\n",
+		"  failed " + filepath.Join(dir, "bad.yaml") + ": ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output is missing %q:\n%s", want, out)
+		}
+	}
+
+	products, err := srv.Client().GetProducts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := make(map[string]string)
+	for _, p := range products {
+		names[p.SKU] = p.Name
+	}
+	want := map[string]string{"A": "Product A", "B": "Bee", "C": "Product C", "D": "Product D"}
+	if len(names) != len(want) {
+		t.Errorf("got products %v, want %v", names, want)
+	}
+	for sku, name := range want {
+		if names[sku] != name {
+			t.Errorf("product %s is named %q, want %q", sku, names[sku], name)
+		}
+	}
+}
@@ -1,40 +1,30 @@
 package products
 
 import (
-	"context"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdProductsDelete returns new initialized instance of the delete sub command
-func NewCmdProductsDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdProductsDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <sku>",
 		Short: "Delete product",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			sku := args[0]
-			ctx := context.Background()
 			products, err := client.GetProducts(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var productID string
 			for _, v := range products {
@@ -44,16 +34,19 @@ func NewCmdProductsDelete() *cobra.Command {
 				}
 			}
 			if productID == "" {
-				fmt.Fprintf(os.Stderr, "product with sku %q not found\n", sku)
-				os.Exit(1)
+				return fmt.Errorf("product with sku %q not found", sku)
 			}
 
+			if err := f.Confirm(yes, "delete product %q (%s)", sku, productID); err != nil {
+				return err
+			}
 			err = client.DeleteProduct(ctx, productID)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
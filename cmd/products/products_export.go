@@ -0,0 +1,157 @@
+package products
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdProductsExport returns new initialized instance of the export sub command
+func NewCmdProductsExport(f *cmdutil.Factory) *cobra.Command {
+	var deleteStale bool
+	var cmd = &cobra.Command{
+		Use:   "export <dir>",
+		Short: "Export products as YAML files for products apply",
+		Long: `Export every product, with its images and prices, to a YAML file named
+after its SKU in dir, in the format read by products apply. dir is
+created if needed and existing files are replaced, so applying the
+exported files to the same store changes nothing.
+
+With --delete-stale, once every product is written, the other product
+files in dir are listed and removed, so dir holds exactly the products
+in the store and does not bring deleted products back. Files that are
+not valid product files, as checked by ecom validate, are left alone.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+
+			products, err := client.GetProducts(ctx)
+			if err != nil {
+				return err
+			}
+			prices, err := client.GetPrices(ctx)
+			if err != nil {
+				return err
+			}
+			// product id -> price list code -> prices
+			productPrices := make(map[string]map[string][]eclient.PriceYAML)
+			for _, p := range prices {
+				m, ok := productPrices[p.ProductID]
+				if !ok {
+					m = make(map[string][]eclient.PriceYAML)
+					productPrices[p.ProductID] = m
+				}
+				m[p.PriceListCode] = append(m[p.PriceListCode], eclient.PriceYAML{
+					Break:     p.Break,
+					UnitPrice: p.UnitPrice,
+				})
+			}
+
+			dir := args[0]
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return fmt.Errorf("create directory %q failed: %w", dir, err)
+			}
+			written := make(map[string]string)
+			for _, p := range products {
+				name := exportFilename(p.SKU)
+				if sku, ok := written[name]; ok {
+					return fmt.Errorf("products with SKUs %q and %q would both be written to %s", sku, p.SKU, name)
+				}
+				written[name] = p.SKU
+
+				images, err := client.GetProductImages(ctx, p.ID)
+				if err != nil {
+					return fmt.Errorf("get images for product sku=%s: %w", p.SKU, err)
+				}
+				container := eclient.ProductContainerYAML{
+					Product: eclient.ProductApplyYAML{
+						Path:   p.Path,
+						SKU:    p.SKU,
+						Name:   p.Name,
+						Images: make([]*eclient.ProductImageApplyYAML, 0, len(images)),
+						Prices: productPrices[p.ID],
+					},
+				}
+				for _, i := range images {
+					container.Product.Images = append(container.Product.Images,
+						&eclient.ProductImageApplyYAML{Path: i.Path})
+				}
+				for _, v := range container.Product.Prices {
+					sort.Slice(v, func(i, j int) bool { return v[i].Break < v[j].Break })
+				}
+
+				data, err := yaml.Marshal(&container)
+				if err != nil {
+					return fmt.Errorf("marshal product sku=%s failed: %w", p.SKU, err)
+				}
+				filename := filepath.Join(dir, name)
+				if err := ioutil.WriteFile(filename, data, 0644); err != nil {
+					return fmt.Errorf("write %q failed: %w", filename, err)
+				}
+			}
+			fmt.Fprintf(f.IOStreams.Out, "Exported %d products to %s.\n", len(products), dir)
+			if deleteStale {
+				stale, err := staleFiles(dir, written)
+				if err != nil {
+					return err
+				}
+				for _, file := range stale {
+					fmt.Fprintf(f.IOStreams.Out, "Removing stale product file %s.\n", file)
+				}
+				for _, file := range stale {
+					if err := os.Remove(file); err != nil {
+						return fmt.Errorf("remove %q failed: %w", file, err)
+					}
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&deleteStale, "delete-stale", false,
+		"remove product files in dir for products that are not in the store")
+	return cmd
+}
+
+// exportFilename returns the name of the file a product is exported to.
+// Path separators in the SKU are replaced.
+func exportFilename(sku string) string {
+	return strings.NewReplacer("/", "_", `\`, "_").Replace(sku) + ".yaml"
+}
+
+// staleFiles returns the product files in dir other than those
+// written.
+func staleFiles(dir string, written map[string]string) ([]string, error) {
+	files, err := cmdutil.YAMLFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	var stale []string
+	for _, file := range files {
+		if _, ok := written[filepath.Base(file)]; ok {
+			continue
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if kind, err := cmdvalidate.ValidateYAML(file, data); err != nil || kind != cmdvalidate.KindProduct {
+			continue
+		}
+		stale = append(stale, file)
+	}
+	return stale, nil
+}
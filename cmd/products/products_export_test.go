@@ -0,0 +1,128 @@
+package products
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclienttest"
+)
+
+// exportFixtures creates a price list and products A, with images and
+// prices, and B, with neither.
+func exportFixtures(t *testing.T, srv *eclienttest.Server) {
+	t.Helper()
+	ctx := context.Background()
+	c := srv.Client()
+	pl, err := c.CreatePriceList(ctx, &eclient.CreatePriceListRequest{
+		PriceListCode: "default",
+		CurrencyCode:  "GBP",
+		Strategy:      "simple",
+		Name:          "Default",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := createProduct(t, srv, "A")
+	createProduct(t, srv, "B")
+	for _, path := range []string{"a1.jpg", "a2.jpg"} {
+		if _, err := c.CreateImage(ctx, eclient.ImageRequest{ProductID: a.ID, Path: path}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = c.SetPrices(ctx, a.ID, pl.ID, []*eclient.PriceRequest{
+		{Break: 10, UnitPrice: 900},
+		{Break: 1, UnitPrice: 1000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProductsExportRoundTrip(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	exportFixtures(t, srv)
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := newTestCmd(srv).run("export", dir); err != nil {
+		t.Fatalf("products export: %v", err)
+	}
+
+	ctx := context.Background()
+	client := srv.Client()
+	c, err := loadCatalog(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, sku := range []string{"A", "B"} {
+		p, err := planProduct(ctx, client, c, filepath.Join(dir, sku+".yaml"))
+		if err != nil {
+			t.Fatalf("plan %s: %v", sku, err)
+		}
+		if p.action != actionUnchanged {
+			t.Errorf("exported %s plans as %s %v, want unchanged", sku, p.action, p.changes)
+		}
+	}
+}
+
+func TestProductsExportDeleteStale(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+	dir, err := ioutil.TempDir("", "export")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"OLD.yaml":    "product:\n  sku: OLD\n  path: old\n  name: Old\n",
+		"OLD2.yml":    "product:\n  sku: OLD2\n  path: old2\n  name: Old 2\n",
+		"catalog.yml": "catalog:\n  segment: root\n  name: Root\n",
+		"broken.yaml": "product:\n  sku: [\n",
+		"notes.txt":   "product:\n  sku: N\n  path: n\n  name: N\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+
+	c := newTestCmd(srv)
+	if err := c.run("export", dir); err != nil {
+		t.Fatalf("products export: %v", err)
+	}
+	for name := range files {
+		if !exists(name) {
+			t.Errorf("export without --delete-stale removed %s", name)
+		}
+	}
+
+	if err := c.run("export", dir, "--delete-stale"); err != nil {
+		t.Fatalf("products export --delete-stale: %v", err)
+	}
+	for name := range files {
+		stale := name == "OLD.yaml" || name == "OLD2.yml"
+		if exists(name) == stale {
+			t.Errorf("%s exists = %v after --delete-stale, want %v", name, !stale, !stale)
+		}
+		if stale && !strings.Contains(c.out.String(), filepath.Join(dir, name)) {
+			t.Errorf("%s not listed in output %q", name, c.out.String())
+		}
+	}
+	if !exists("A.yaml") {
+		t.Error("A.yaml removed")
+	}
+}
@@ -1,41 +1,33 @@
 package products
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdProductsGet returns new initialized instance of the get sub command
-func NewCmdProductsGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdProductsGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get <sku>",
 		Short: "Get product",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			sku := args[0]
-			ctx := context.Background()
 			products, err := client.GetProducts(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var productID string
 			for _, v := range products {
@@ -45,32 +37,24 @@ func NewCmdProductsGet() *cobra.Command {
 				}
 			}
 			if productID == "" {
-				fmt.Fprintf(os.Stderr, "product with sku %q not found\n", sku)
-				os.Exit(1)
+				return fmt.Errorf("product with sku %q not found", sku)
 			}
 
 			product, err := client.GetProduct(ctx, productID)
-			if err == eclient.ErrProductNotFound {
-				fmt.Printf("Product %s not found.\n", sku)
-				os.Exit(0)
+			if errors.Is(err, eclient.ErrProductNotFound) {
+				fmt.Fprintf(f.IOStreams.Out, "Product %s not found.\n", sku)
+				return nil
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Product ID:", product.ID)
-			fmt.Fprintf(tw, format, "Path:", product.Path)
-			fmt.Fprintf(tw, format, "SKU:", product.SKU)
-			fmt.Fprintf(tw, format, "Name:", product.Name)
-			fmt.Fprintf(tw, format, "Created:",
-				product.Created.In(location).Format(timeDisplayFormat))
-			fmt.Fprintf(tw, format, "Modified:",
-				product.Modified.In(location).Format(timeDisplayFormat))
-			tw.Flush()
+			t := output.NewRecord("Product ID", "Path", "SKU", "Name", "Created", "Modified")
+			t.Row(product.ID, product.Path, product.SKU, product.Name,
+				product.Created, product.Modified)
+			return out.Print(f.IOStreams.Out, product, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
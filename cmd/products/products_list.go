@@ -1,70 +1,39 @@
 package products
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v", "Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdProductsList returns new initialized instance of the get sub command.
-func NewCmdProductsList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdProductsList(f *cmdutil.Factory) *cobra.Command {
+	var opts eclient.ListOptions
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "list products",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
-			products, err := client.GetProducts(ctx)
+			products, err := client.Products(&opts).All(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%s\t%s\t%v\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Product ID", "Path", "SKU",
-				"Name", "Created", "Modified")
-			fmt.Fprintf(tw, format, "----------", "----", "---",
-				"----", "-------", "--------")
-
+			t := output.NewTable("Product ID", "Path", "SKU", "Name", "Created", "Modified")
 			for _, v := range products {
-				fmt.Fprintf(tw, format,
-					v.ID, v.Path, v.SKU, v.Name,
-					v.Created.In(location).Format(timeDisplayFormat),
-					v.Modified.In(location).Format(timeDisplayFormat))
+				t.Row(v.ID, v.Path, v.SKU, v.Name, v.Created, v.Modified)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, products, t)
 		},
 	}
+	cmdutil.AddListFlags(cmd, &opts)
+	output.AddFlag(cmd, &out)
 	return cmd
 }
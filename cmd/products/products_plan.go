@@ -0,0 +1,335 @@
+package products
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+// Plan actions.
+const (
+	actionCreate    = "create"
+	actionUpdate    = "update"
+	actionUnchanged = "unchanged"
+	actionDelete    = "delete"
+	actionSkip      = "skip"
+	actionFailed    = "failed"
+)
+
+// catalog is the live state products apply compares the YAML files
+// with.
+type catalog struct {
+	products   map[string]*eclient.ProductResponse // by SKU
+	priceLists map[string]*eclient.PriceList       // by price list code
+	prices     map[string]map[string][]*eclient.Price
+}
+
+// loadCatalog fetches the products, price lists and prices.
+func loadCatalog(ctx context.Context, ec *eclient.EcomClient) (*catalog, error) {
+	products, err := ec.GetProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	priceLists, err := ec.GetPriceLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	prices, err := ec.GetPrices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := catalog{
+		products:   make(map[string]*eclient.ProductResponse, len(products)),
+		priceLists: make(map[string]*eclient.PriceList, len(priceLists)),
+		prices:     make(map[string]map[string][]*eclient.Price),
+	}
+	for _, p := range products {
+		c.products[p.SKU] = p
+	}
+	for _, pl := range priceLists {
+		c.priceLists[pl.PriceListCode] = pl
+	}
+	// product id -> price list code -> prices
+	for _, p := range prices {
+		m, ok := c.prices[p.ProductID]
+		if !ok {
+			m = make(map[string][]*eclient.Price)
+			c.prices[p.ProductID] = m
+		}
+		m[p.PriceListCode] = append(m[p.PriceListCode], p)
+	}
+	return &c, nil
+}
+
+// productPlan is what applying a product file changes, or, for
+// --prune, a live product with no file that is to be deleted.
+type productPlan struct {
+	file    string // empty for deletes
+	sku     string
+	action  string
+	reason  string   // why the file is skipped
+	changes []string // field-level differences, for updates
+	err     error    // why planning or applying failed
+	applied bool
+
+	product        *eclient.ProductResponse // nil until created
+	request        eclient.ProductRequest
+	replaceProduct bool
+	images         []*eclient.ProductImageApplyYAML
+	replaceImages  bool
+	prices         map[string][]*eclient.PriceRequest // by price list ID
+}
+
+// planProduct compares the product file filename with the catalog.
+// Files that cannot be applied are planned as skipped, with the reason.
+func planProduct(ctx context.Context, ec *eclient.EcomClient, c *catalog, filename string) (*productPlan, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open(%q) failed: %w", filename, err)
+	}
+	defer file.Close()
+
+	container := eclient.ProductContainerYAML{}
+	dec := yaml.NewDecoder(file)
+	if err := dec.Decode(&container); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("decode %s: %w", filename, err)
+	}
+	y := container.Product
+
+	p := productPlan{
+		file: filename,
+		sku:  y.SKU,
+		request: eclient.ProductRequest{
+			Path: y.Path,
+			SKU:  y.SKU,
+			Name: y.Name,
+		},
+		images: y.Images,
+		prices: make(map[string][]*eclient.PriceRequest),
+	}
+	if y.SKU == "" {
+		p.action, p.reason = actionSkip, "no sku"
+		return &p, nil
+	}
+	codes := make([]string, 0, len(y.Prices))
+	for code := range y.Prices {
+		if _, ok := c.priceLists[code]; !ok {
+			p.action, p.reason = actionSkip, fmt.Sprintf("price list %q not found", code)
+			return &p, nil
+		}
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	p.product = c.products[y.SKU]
+	if p.product == nil {
+		p.action = actionCreate
+		p.replaceImages = len(y.Images) > 0
+		for _, code := range codes {
+			p.prices[c.priceLists[code].ID] = priceRequests(y.Prices[code])
+		}
+		return &p, nil
+	}
+
+	if p.product.Path != y.Path {
+		p.replaceProduct = true
+		p.changes = append(p.changes, fmt.Sprintf("path: %q -> %q", p.product.Path, y.Path))
+	}
+	if p.product.Name != y.Name {
+		p.replaceProduct = true
+		p.changes = append(p.changes, fmt.Sprintf("name: %q -> %q", p.product.Name, y.Name))
+	}
+
+	images, err := ec.GetProductImages(ctx, p.product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get images for product sku=%s: %w", y.SKU, err)
+	}
+	live := make([]string, 0, len(images))
+	for _, i := range images {
+		live = append(live, i.Path)
+	}
+	want := make([]string, 0, len(y.Images))
+	for _, i := range y.Images {
+		want = append(want, i.Path)
+	}
+	if strings.Join(live, "\n") != strings.Join(want, "\n") {
+		p.replaceImages = true
+		p.changes = append(p.changes, fmt.Sprintf("images: [%s] -> [%s]",
+			strings.Join(live, ", "), strings.Join(want, ", ")))
+	}
+
+	for _, code := range codes {
+		pl := c.priceLists[code]
+		from := formatPrices(livePrices(c.prices[p.product.ID][code]), pl.CurrencyCode)
+		to := formatPrices(y.Prices[code], pl.CurrencyCode)
+		if from != to {
+			p.prices[pl.ID] = priceRequests(y.Prices[code])
+			p.changes = append(p.changes, fmt.Sprintf("prices[%s]: %s -> %s", code, from, to))
+		}
+	}
+
+	p.action = actionUnchanged
+	if len(p.changes) > 0 {
+		p.action = actionUpdate
+	}
+	return &p, nil
+}
+
+// planPrune plans the deletion of the live products whose SKUs are in
+// none of the planned files, apart from those matching an exclude
+// pattern.
+func planPrune(c *catalog, plans []*productPlan, exclude []string) []*productPlan {
+	keep := make(map[string]bool, len(plans))
+	for _, p := range plans {
+		keep[p.sku] = true
+	}
+	skus := make([]string, 0, len(c.products))
+	for sku := range c.products {
+		if !keep[sku] && !excluded(sku, exclude) {
+			skus = append(skus, sku)
+		}
+	}
+	sort.Strings(skus)
+
+	deletes := make([]*productPlan, 0, len(skus))
+	for _, sku := range skus {
+		deletes = append(deletes, &productPlan{
+			sku:     sku,
+			action:  actionDelete,
+			product: c.products[sku],
+		})
+	}
+	return deletes
+}
+
+// excluded reports whether sku matches any of the glob patterns.
+// The patterns are checked by the caller.
+func excluded(sku string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, sku); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func priceRequests(prices []eclient.PriceYAML) []*eclient.PriceRequest {
+	r := make([]*eclient.PriceRequest, 0, len(prices))
+	for _, price := range prices {
+		r = append(r, &eclient.PriceRequest{
+			Break:     price.Break,
+			UnitPrice: price.UnitPrice,
+		})
+	}
+	return r
+}
+
+func livePrices(prices []*eclient.Price) []eclient.PriceYAML {
+	r := make([]eclient.PriceYAML, 0, len(prices))
+	for _, p := range prices {
+		r = append(r, eclient.PriceYAML{Break: p.Break, UnitPrice: p.UnitPrice})
+	}
+	return r
+}
+
+// formatPrices lists the price breaks in order, for example
+// "1 @ £10.00, 10 @ £9.00".
+func formatPrices(prices []eclient.PriceYAML, currency string) string {
+	sorted := make([]eclient.PriceYAML, len(prices))
+	copy(sorted, prices)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Break < sorted[j].Break })
+	s := make([]string, 0, len(sorted))
+	for _, p := range sorted {
+		s = append(s, fmt.Sprintf("%d @ %s", p.Break, display.Price(p.UnitPrice, currency)))
+	}
+	if len(s) == 0 {
+		return "none"
+	}
+	return strings.Join(s, ", ")
+}
+
+// print writes the plan for the product, followed by its changes.
+func (p *productPlan) print(w io.Writer) {
+	switch p.action {
+	case actionCreate:
+		fmt.Fprintf(w, "+ create %s (%s)\n", p.sku, p.file)
+	case actionUpdate:
+		fmt.Fprintf(w, "~ update %s (%s)\n", p.sku, p.file)
+		for _, c := range p.changes {
+			fmt.Fprintf(w, "    %s\n", c)
+		}
+	case actionUnchanged:
+		fmt.Fprintf(w, "= unchanged %s (%s)\n", p.sku, p.file)
+	case actionDelete:
+		fmt.Fprintf(w, "- delete %s (%s)\n", p.sku, p.product.ID)
+	case actionSkip:
+		fmt.Fprintf(w, "! skip %s: %s\n", p.file, p.reason)
+	case actionFailed:
+		fmt.Fprintf(w, "! failed %s: %v\n", p.file, p.err)
+	}
+}
+
+// name identifies the plan in the summary: its file, or the SKU of a
+// product to delete.
+func (p *productPlan) name() string {
+	if p.action == actionDelete {
+		return p.sku
+	}
+	return p.file
+}
+
+// apply sends the calls needed to carry out the plan.
+func (p *productPlan) apply(ctx context.Context, ec *eclient.EcomClient) error {
+	var err error
+	switch {
+	case p.action == actionDelete:
+		if err := ec.DeleteProduct(ctx, p.product.ID); err != nil {
+			return fmt.Errorf("delete product sku=%s: %w", p.sku, err)
+		}
+		return nil
+	case p.action == actionCreate:
+		if p.product, err = ec.CreateProduct(ctx, &p.request); err != nil {
+			return err
+		}
+	case p.action == actionUpdate && p.replaceProduct:
+		if _, err = ec.ReplaceProduct(ctx, p.product.ID, &p.request); err != nil {
+			return err
+		}
+	case p.action != actionUpdate:
+		return nil
+	}
+
+	if p.replaceImages {
+		if p.action == actionUpdate {
+			if err := ec.DeleteProductImages(ctx, p.product.ID); err != nil {
+				return fmt.Errorf("delete images for product sku=%s: %w", p.sku, err)
+			}
+		}
+		for _, i := range p.images {
+			ir := eclient.ImageRequest{
+				ProductID: p.product.ID,
+				Path:      i.Path,
+			}
+			if _, err := ec.CreateImage(ctx, ir); err != nil {
+				return fmt.Errorf("create image for product sku=%s: %w", p.sku, err)
+			}
+		}
+	}
+
+	for priceListID, prices := range p.prices {
+		if _, err := ec.SetPrices(ctx, p.product.ID, priceListID, prices); err != nil {
+			return fmt.Errorf("set prices for product sku=%s: %w", p.sku, err)
+		}
+	}
+	return nil
+}
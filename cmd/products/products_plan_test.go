@@ -0,0 +1,157 @@
+package products
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclienttest"
+)
+
+func TestPlanProduct(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	exportFixtures(t, srv)
+
+	const a = `product:
+  sku: A
+  path: a
+  name: Product A
+  images:
+    - path: a1.jpg
+    - path: a2.jpg
+  prices:
+    default:
+      - break: 1
+        unit_price: 1000
+      - break: 10
+        unit_price: 900
+`
+	dir := writeFiles(t, map[string]string{
+		"unchanged.yaml": a,
+		"reordered.yaml": `product:
+  sku: A
+  path: a
+  name: Product A
+  images:
+    - path: a1.jpg
+    - path: a2.jpg
+  prices:
+    default:
+      - break: 10
+        unit_price: 900
+      - break: 1
+        unit_price: 1000
+`,
+		"update.yaml": `product:
+  sku: B
+  path: bee
+  name: Bee
+  images:
+    - path: b.jpg
+  prices:
+    default:
+      - break: 1
+        unit_price: 50000
+`,
+		"prices.yaml": strings.Replace(a, "unit_price: 900", "unit_price: 950", 1),
+		"images.yaml": strings.Replace(a, "    - path: a2.jpg\n", "", 1),
+		"create.yaml": productFile("C", "Product C"),
+		"no-sku.yaml": "product:\n  path: d\n  name: D\n",
+		"price-list.yaml": `product:
+  sku: E
+  path: e
+  name: E
+  prices:
+    trade:
+      - break: 1
+        unit_price: 1
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		file    string
+		action  string
+		changes []string
+		reason  string
+	}{
+		{"unchanged.yaml", actionUnchanged, nil, ""},
+		{"reordered.yaml", actionUnchanged, nil, ""},
+		{"update.yaml", actionUpdate, []string{
+			`path: "b" -> "bee"`,
+			`name: "Product B" -> "Bee"`,
+			"images: [] -> [b.jpg]",
+			"prices[default]: none -> 1 @ £5.00",
+		}, ""},
+		{"prices.yaml", actionUpdate, []string{
+			"prices[default]: 1 @ £0.10, 10 @ £0.09 -> 1 @ £0.10, 10 @ £0.0950",
+		}, ""},
+		{"images.yaml", actionUpdate, []string{"images: [a1.jpg, a2.jpg] -> [a1.jpg]"}, ""},
+		{"create.yaml", actionCreate, nil, ""},
+		{"no-sku.yaml", actionSkip, nil, "no sku"},
+		{"price-list.yaml", actionSkip, nil, `price list "trade" not found`},
+	}
+	ctx := context.Background()
+	client := srv.Client()
+	c, err := loadCatalog(ctx, client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			p, err := planProduct(ctx, client, c, filepath.Join(dir, tt.file))
+			if err != nil {
+				t.Fatalf("planProduct: %v", err)
+			}
+			if p.action != tt.action {
+				t.Errorf("action = %s, want %s", p.action, tt.action)
+			}
+			if !reflect.DeepEqual(p.changes, tt.changes) {
+				t.Errorf("changes = %q, want %q", p.changes, tt.changes)
+			}
+			if p.reason != tt.reason {
+				t.Errorf("reason = %q, want %q", p.reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestProductsApplyDryRun(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+	createProduct(t, srv, "B")
+	dir := writeFiles(t, map[string]string{
+		"a.yaml": productFile("A", "Product A"),
+		"b.yaml": productFile("B", "Bee"),
+		"c.yaml": productFile("C", "Product C"),
+	})
+	defer os.RemoveAll(dir)
+
+	c := newTestCmd(srv)
+	if err := c.run("apply", dir, "--dry-run"); err != nil {
+		t.Fatalf("products apply --dry-run: %v", err)
+	}
+	for _, want := range []string{
+		"= unchanged A (" + filepath.Join(dir, "a.yaml") + ")\n",
+		"~ update B (" + filepath.Join(dir, "b.yaml") + ")\n" + `    name: "Product B" -> "Bee"` + "\n",
+		"+ create C (" + filepath.Join(dir, "c.yaml") + ")\n",
+		"Plan: 1 to create, 1 to update, 0 to delete, 1 unchanged, 0 skipped, 0 failed.\n",
+	} {
+		if !strings.Contains(c.out.String(), want) {
+			t.Errorf("plan is missing %q:\n%s", want, c.out.String())
+		}
+	}
+
+	products, err := srv.Client().GetProducts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 2 || products[1].Name != "Product B" {
+		t.Errorf("--dry-run changed the products: %+v", products)
+	}
+}
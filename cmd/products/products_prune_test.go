@@ -0,0 +1,155 @@
+package products
+
+import (
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclienttest"
+)
+
+// liveSKUs returns the SKUs of the products on srv, sorted.
+func liveSKUs(t *testing.T, srv *eclienttest.Server) string {
+	t.Helper()
+	products, err := srv.Client().GetProducts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	skus := make([]string, 0, len(products))
+	for _, p := range products {
+		skus = append(skus, p.SKU)
+	}
+	sort.Strings(skus)
+	return strings.Join(skus, " ")
+}
+
+func TestProductsApplyPrune(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml": productFile("A", "Product A"),
+		"c.yml":  productFile("C", "Product C"),
+	})
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+		out  []string
+		want string // SKUs left
+	}{
+		{
+			name: "prune",
+			args: []string{"--yes"},
+			out: []string{
+				"- delete B (",
+				"- delete GIFT-1 (",
+				"Plan: 1 to create, 0 to update, 3 to delete, 1 unchanged, 0 skipped, 0 failed.\n",
+				"Summary: 1 created, 0 updated, 3 deleted, 1 unchanged, 0 skipped, 0 failed.\n",
+			},
+			want: "A C",
+		},
+		{
+			name: "exclude",
+			args: []string{"--yes", "--exclude", "GIFT-*"},
+			out:  []string{"- delete B (", "1 to create, 0 to update, 1 to delete,"},
+			want: "A C GIFT-1 GIFT-2",
+		},
+		{
+			name: "exclude several",
+			args: []string{"--yes", "--exclude", "GIFT-1", "--exclude", "B"},
+			out:  []string{"- delete GIFT-2 (", "1 to create, 0 to update, 1 to delete,"},
+			want: "A B C GIFT-1",
+		},
+		{
+			name: "dry run",
+			args: []string{"--dry-run"},
+			out:  []string{"- delete B (", "1 to create, 0 to update, 3 to delete,"},
+			want: "A B GIFT-1 GIFT-2",
+		},
+		{
+			name: "not confirmed",
+			err:  "stdin is not a terminal; use --yes to confirm",
+			want: "A B GIFT-1 GIFT-2",
+		},
+		{
+			name: "bad exclude",
+			args: []string{"--yes", "--exclude", "GIFT-["},
+			err:  `--exclude "GIFT-["`,
+			want: "A B GIFT-1 GIFT-2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := eclienttest.NewServer()
+			defer srv.Close()
+			for _, sku := range []string{"A", "B", "GIFT-1", "GIFT-2"} {
+				createProduct(t, srv, sku)
+			}
+
+			c := newTestCmd(srv)
+			err := c.run(append([]string{"apply", dir, "--prune"}, tt.args...)...)
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("products apply --prune: %v", err)
+			case tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)):
+				t.Fatalf("products apply --prune = %v, want an error containing %q", err, tt.err)
+			}
+			for _, want := range tt.out {
+				if !strings.Contains(c.out.String(), want) {
+					t.Errorf("output is missing %q:\n%s", want, c.out.String())
+				}
+			}
+			if got := liveSKUs(t, srv); got != tt.want {
+				t.Errorf("products are %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductsApplyPruneRefused(t *testing.T) {
+	empty := writeFiles(t, nil)
+	defer os.RemoveAll(empty)
+	notProducts := writeFiles(t, map[string]string{"notes.txt": productFile("A", "Product A")})
+	defer os.RemoveAll(notProducts)
+	failing := writeFiles(t, map[string]string{
+		"a.yaml":   productFile("A", "Product A"),
+		"bad.yaml": productFile("BAD", "Bad"),
+	})
+	defer os.RemoveAll(failing)
+
+	tests := []struct {
+		name string
+		dir  string
+		err  string
+		out  string
+	}{
+		{"empty directory", empty, "no product files in " + empty + "; refusing to prune every product", ""},
+		{"no YAML files", notProducts, "no product files in " + notProducts, ""},
+		{"file", failing + "/a.yaml", "--prune needs a directory of product files", ""},
+		{"a file fails", failing, "1 of 3 products failed", "Not pruning, as 1 products failed.\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := eclienttest.NewServer()
+			defer srv.Close()
+			createProduct(t, srv, "A")
+			createProduct(t, srv, "B")
+
+			c := newTestCmd(srv)
+			c.opts = []eclient.Option{eclient.WithTransport(failSKU("BAD"))}
+			err := c.run("apply", tt.dir, "--prune", "--yes")
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("products apply --prune = %v, want an error containing %q", err, tt.err)
+			}
+			if !strings.Contains(c.out.String(), tt.out) {
+				t.Errorf("output is missing %q:\n%s", tt.out, c.out.String())
+			}
+			if got := liveSKUs(t, srv); got != "A B" {
+				t.Errorf("products are %s, want A B", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package products
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclienttest"
+)
+
+// testCmd runs the products commands against srv with a Factory that
+// has no config file and captures the output streams.
+type testCmd struct {
+	srv     *eclienttest.Server
+	profile configmgr.EcomConfigEntry
+	opts    []eclient.Option // for the client
+	in      string
+	out     bytes.Buffer
+	errOut  bytes.Buffer
+}
+
+func newTestCmd(srv *eclienttest.Server) *testCmd {
+	return &testCmd{
+		srv:     srv,
+		profile: configmgr.EcomConfigEntry{Endpoint: srv.URL},
+	}
+}
+
+func (c *testCmd) run(args ...string) error {
+	c.out.Reset()
+	c.errOut.Reset()
+	f := &cmdutil.Factory{
+		IOStreams: cmdutil.IOStreams{
+			In:     strings.NewReader(c.in),
+			Out:    &c.out,
+			ErrOut: &c.errOut,
+		},
+		Config: func() (*configmgr.EcomConfigurations, string, error) {
+			return &configmgr.EcomConfigurations{
+				Configurations: map[string]configmgr.EcomConfigEntry{"test": c.profile},
+			}, "test", nil
+		},
+		Client: func(ctx context.Context) (*eclient.EcomClient, error) {
+			return c.srv.Client(c.opts...), nil
+		},
+	}
+	cmd := NewCmdProducts(f)
+	cmd.SetArgs(args)
+	cmd.SetOutput(&c.errOut)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	return cmd.Execute()
+}
+
+func createProduct(t *testing.T, srv *eclienttest.Server, sku string) *eclient.ProductResponse {
+	t.Helper()
+	p, err := srv.Client().CreateProduct(context.Background(), &eclient.ProductRequest{
+		SKU:  sku,
+		Path: strings.ToLower(sku),
+		Name: "Product " + sku,
+	})
+	if err != nil {
+		t.Fatalf("CreateProduct(%s): %v", sku, err)
+	}
+	return p
+}
+
+// writeFiles writes each file, by name, to a new directory and returns
+// its path.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "products")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644); err != nil {
+			os.RemoveAll(dir)
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// productFile returns the contents of a product file.
+func productFile(sku, name string) string {
+	return fmt.Sprintf("product:\n  sku: %s\n  path: %s\n  name: %s\n", sku, strings.ToLower(sku), name)
+}
+
+func TestProductsList(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+	createProduct(t, srv, "B")
+
+	c := newTestCmd(srv)
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"list", "-o", "jsonpath={[*].sku}"}, "A B"},
+		{[]string{"list", "--limit", "1", "-o", "jsonpath={[*].sku}"}, "A"},
+		{[]string{"list", "-o", "template={{range .}}{{.path}}/{{.name}};{{end}}"}, "a/Product A;b/Product B;"},
+	}
+	for _, tt := range tests {
+		if err := c.run(tt.args...); err != nil {
+			t.Fatalf("products %s: %v", strings.Join(tt.args, " "), err)
+		}
+		if c.out.String() != tt.want {
+			t.Errorf("products %s printed %q, want %q", strings.Join(tt.args, " "), c.out.String(), tt.want)
+		}
+	}
+}
+
+func TestProductsDelete(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+	createProduct(t, srv, "B")
+
+	c := newTestCmd(srv)
+	if err := c.run("delete", "A"); err != nil {
+		t.Fatalf("products delete A: %v", err)
+	}
+	err := c.run("delete", "A")
+	if err == nil || err.Error() != `product with sku "A" not found` {
+		t.Errorf("second products delete A error = %v, want not found", err)
+	}
+
+	products, err := srv.Client().GetProducts(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 1 || products[0].SKU != "B" {
+		t.Errorf("products left are %v, want only B", products)
+	}
+}
+
+func TestProductsDeleteProtected(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+
+	c := newTestCmd(srv)
+	c.profile.Environment = configmgr.ProductionEnvironment
+	c.in = "test\n"
+	err := c.run("delete", "A")
+	if err == nil || !strings.Contains(err.Error(), "use --yes") {
+		t.Fatalf("products delete on a protected profile with stdin not a terminal = %v, want --yes required", err)
+	}
+	if err := c.run("delete", "A", "--yes"); err != nil {
+		t.Fatalf("products delete --yes: %v", err)
+	}
+}
+
+func TestProductsDeleteEphemeral(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	createProduct(t, srv, "A")
+
+	for k, v := range map[string]string{configmgr.EndpointEnv: srv.URL, configmgr.DevKeyEnv: srv.DevKey} {
+		old, ok := os.LookupEnv(k)
+		os.Setenv(k, v)
+		if ok {
+			defer os.Setenv(k, old)
+		} else {
+			defer os.Unsetenv(k)
+		}
+	}
+	c := newTestCmd(srv)
+	err := c.run("delete", "A")
+	if err == nil || !strings.Contains(err.Error(), "use --yes") {
+		t.Fatalf("products delete with an ephemeral profile = %v, want --yes required", err)
+	}
+	if err := c.run("delete", "A", "--yes"); err != nil {
+		t.Fatalf("products delete --yes: %v", err)
+	}
+}
@@ -1,18 +1,25 @@
 package profiles
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdProfiles returns new initialized instance of profiles sub command
-func NewCmdProfiles() *cobra.Command {
+func NewCmdProfiles(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "profiles",
 		Short: "Profile management",
 	}
-	cmd.AddCommand(NewCmdProfilesCreate())
-	cmd.AddCommand(NewCmdProfilesList())
-	cmd.AddCommand(NewCmdProfilesRemove())
-	cmd.AddCommand(NewCmdProfilesSelect())
+	cmd.AddCommand(NewCmdProfilesCreate(f))
+	cmd.AddCommand(NewCmdProfilesDoctor(f))
+	cmd.AddCommand(NewCmdProfilesExport(f))
+	cmd.AddCommand(NewCmdProfilesImport(f))
+	cmd.AddCommand(NewCmdProfilesList(f))
+	cmd.AddCommand(NewCmdProfilesRemove(f))
+	cmd.AddCommand(NewCmdProfilesRename(f))
+	cmd.AddCommand(NewCmdProfilesSelect(f))
+	cmd.AddCommand(NewCmdProfilesSet(f))
+	cmd.AddCommand(NewCmdProfilesShow(f))
 	return cmd
 }
@@ -4,53 +4,81 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdProfilesCreate returns new initialized instance of create sub command
-func NewCmdProfilesCreate() *cobra.Command {
-	cfgs, _, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdProfilesCreate(f *cmdutil.Factory) *cobra.Command {
+	var endpoint, devKey, name, environment string
+	var overwrite bool
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a new profile",
-		Run: func(cmd *cobra.Command, args []string) {
-			endpoint, devKey, err := promptAddProfile()
+		Long: `Create a new profile by signing in with a developer key.
+
+The endpoint and developer key are taken from the --api-endpoint and
+--developer-key flags, then the ECOM_ENDPOINT and ECOM_DEVELOPER_KEY
+environment variables, and are prompted for if still unset.
+
+An existing profile of the same name, or one signed in with the same
+developer key, is only replaced with --overwrite.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if endpoint == "" {
+				endpoint = os.Getenv(configmgr.EndpointEnv)
+			}
+			if devKey == "" {
+				devKey = os.Getenv(configmgr.DevKeyEnv)
+			}
+			if err := promptAddProfile(&endpoint, &devKey); err != nil {
+				return err
+			}
+
+			entry := configmgr.EcomConfigEntry{
+				DevKey:      devKey,
+				Endpoint:    endpoint,
+				Environment: environment,
+			}
+			filename, err := configmgr.TokenName(&entry)
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				name = filename
+			}
+			cfgs, err := configmgr.ReadConfig()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
+			}
+			if err := cmdutil.CheckNewProfile(cfgs, name, &entry, overwrite); err != nil {
+				return err
 			}
-			client := eclient.New(endpoint)
-			g, err := client.GetConfig()
+
+			ctx := cmdutil.Context()
+			client, err := cmdutil.NewClient(&entry)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
-			customToken, user, err := client.SignInWithDevKey(devKey)
+			g, err := client.GetConfig(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
-			tar, err := client.ExchangeCustomTokenForIDAndRefreshToken(g.APIKEY, customToken)
+			customToken, user, err := client.SignInWithDevKey(ctx, devKey)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
-
-			hostname, err := configmgr.URLToHostName(endpoint)
-			filename := fmt.Sprintf("%s-%s", hostname, devKey[:6])
-			configmgr.WriteTokenAndRefreshToken(filename, tar)
-			if cfgs.Configurations == nil {
-				cfgs.Configurations = make(map[string]configmgr.EcomConfigEntry)
+			tar, err := client.ExchangeCustomTokenForIDAndRefreshToken(ctx, g.APIKEY, customToken)
+			if err != nil {
+				return err
 			}
 
-			c := configmgr.Customer{
+			if err := configmgr.WriteTokenAndRefreshToken(filename, tar); err != nil {
+				return err
+			}
+			entry.Customer = configmgr.Customer{
 				ID:        user.ID,
 				UID:       user.UID,
 				Role:      user.Role,
@@ -58,28 +86,50 @@ func NewCmdProfilesCreate() *cobra.Command {
 				Firstname: user.Firstname,
 				Lastname:  user.Lastname,
 			}
-			cfgs.Configurations[filename] = configmgr.EcomConfigEntry{
-				DevKey:   devKey,
-				Endpoint: endpoint,
-				Customer: c,
-			}
-			if err = configmgr.WriteConfig(cfgs); err != nil {
-				fmt.Fprintf(os.Stderr, "write config failed: %+v", err)
-				os.Exit(1)
+			err = configmgr.UpdateConfig(func(cfgs *configmgr.EcomConfigurations) error {
+				if err := cmdutil.CheckNewProfile(cfgs, name, &entry, overwrite); err != nil {
+					return err
+				}
+				cfgs.Configurations[name] = entry
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("write config failed: %w", err)
 			}
+			fmt.Fprintf(f.IOStreams.Out, "Profile %q created.\n", name)
+			return nil
 		},
 	}
+	cmd.Flags().StringVar(&endpoint, "api-endpoint", "",
+		"API endpoint of the new profile (default from ECOM_ENDPOINT)")
+	cmd.Flags().StringVar(&devKey, "developer-key", "",
+		"developer key to sign in with (default from ECOM_DEVELOPER_KEY)")
+	cmd.Flags().StringVar(&environment, "environment", "",
+		"tag the profile, for example production to ask for confirmation before destructive commands")
+	cmd.Flags().StringVar(&name, "name", "",
+		"name of the new profile (default <hostname>-<first 6 characters of the developer key>)")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace an existing profile of the same name")
 	return cmd
 }
 
-func promptAddProfile() (endpoint, devKey string, err error) {
-	e := &survey.Input{
-		Message: "Endpoint:",
+// promptAddProfile prompts for the endpoint and developer key if they
+// are empty.
+func promptAddProfile(endpoint, devKey *string) error {
+	if *endpoint == "" {
+		e := &survey.Input{
+			Message: "Endpoint:",
+		}
+		if err := survey.AskOne(e, endpoint, survey.Required); err != nil {
+			return err
+		}
 	}
-	survey.AskOne(e, &endpoint, nil)
-	d := &survey.Password{
-		Message: "Developer Key:",
+	if *devKey == "" {
+		d := &survey.Password{
+			Message: "Developer Key:",
+		}
+		if err := survey.AskOne(d, devKey, survey.Required); err != nil {
+			return err
+		}
 	}
-	survey.AskOne(d, &devKey, nil)
-	return endpoint, devKey, nil
+	return nil
 }
@@ -0,0 +1,112 @@
+package profiles
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdProfilesDoctor returns new initialized instance of doctor sub command
+func NewCmdProfilesDoctor(f *cmdutil.Factory) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "doctor [name]",
+		Short: "Check a profile for problems",
+		Long: `Check the named profile, or the selected profile: that the config can
+be read, that the profile has a token, that the endpoint is reachable
+and which API version it runs.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
+			var failed int
+			report := func(check string, err error, detail string) {
+				status := "ok"
+				if err != nil {
+					status = "FAIL"
+					detail = err.Error()
+					failed++
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\n", check, status, detail)
+			}
+			defer tw.Flush()
+
+			cfgs, curCfg, err := f.Config()
+			if err != nil {
+				report("config", err, "")
+				return fmt.Errorf("config cannot be read")
+			}
+			name := curCfg
+			if len(args) > 0 {
+				name = args[0]
+			}
+			e, ok := cfgs.Configurations[name]
+			switch {
+			case name == "":
+				err = fmt.Errorf("no profile selected")
+			case !ok:
+				err = fmt.Errorf("profile %q not found", name)
+			default:
+				_, err = configmgr.URLToHostName(e.Endpoint)
+			}
+			report("config", err, fmt.Sprintf("profile %q, endpoint %s", name, e.Endpoint))
+			if err != nil {
+				return fmt.Errorf("profile %q is not usable", name)
+			}
+
+			tokenName, expires, err := tokenExpiry(&e)
+			switch {
+			case err != nil && e.DevKey != "":
+				fmt.Fprintf(tw, "token\twarn\t%v; signs in with the developer key on next use\n", err)
+			case err != nil:
+				report("token", err, "")
+			case expires.Before(time.Now()):
+				fmt.Fprintf(tw, "token\twarn\t%s expired %s; refreshed on next use\n",
+					tokenName, display.Time(*expires))
+			default:
+				report("token", nil, fmt.Sprintf("%s expires %s",
+					tokenName, display.Time(*expires)))
+			}
+
+			ctx := cmdutil.Context()
+			client, err := cmdutil.NewClient(&e)
+			if err != nil {
+				report("endpoint", err, "")
+				return fmt.Errorf("%d checks failed", failed)
+			}
+			g, err := client.GetConfig(ctx)
+			var detail string
+			if err == nil {
+				detail = fmt.Sprintf("%s reachable, Firebase project %s", e.Endpoint, g.ProjectID)
+			}
+			report("endpoint", err, detail)
+			if err != nil {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+
+			if err := client.SetToken(ctx, &e); err != nil {
+				if e.DevKey == "" {
+					report("api", err, "")
+					return fmt.Errorf("%d checks failed", failed)
+				}
+				if _, err := client.SignIn(ctx, &e); err != nil {
+					report("api", fmt.Errorf("sign in with developer key failed: %w", err), "")
+					return fmt.Errorf("%d checks failed", failed)
+				}
+			}
+			sysInfo, err := client.SysInfo(ctx)
+			if err == nil {
+				detail = "API version " + sysInfo.APIVersion
+			}
+			report("api", err, detail)
+			if failed > 0 {
+				return fmt.Errorf("%d checks failed", failed)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,91 @@
+package profiles
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// exportFile is the format written by profiles export and read by
+// profiles import.
+type exportFile struct {
+	Profiles map[string]exportedProfile `yaml:"profiles"`
+}
+
+// exportedProfile is a profile together with its developer key and,
+// optionally, its token.
+type exportedProfile struct {
+	configmgr.EcomConfigEntry `yaml:",inline"`
+	Token                     *configmgr.TokenAndRefreshToken `yaml:"token,omitempty"`
+}
+
+// NewCmdProfilesExport returns new initialized instance of export sub command
+func NewCmdProfilesExport(f *cmdutil.Factory) *cobra.Command {
+	var file string
+	var includeTokens bool
+	var cmd = &cobra.Command{
+		Use:   "export [name...]",
+		Short: "Export profiles to move them to another machine",
+		Long: `Export the named profiles, or all profiles, as YAML for profiles import.
+
+The export includes the developer keys, and the tokens if
+--include-tokens is given, so keep it safe. Files written with --file
+are readable only by the owner.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgs, _, err := f.Config()
+			if err != nil {
+				return err
+			}
+			names := args
+			if len(names) == 0 {
+				for name := range cfgs.Configurations {
+					names = append(names, name)
+				}
+				sort.Strings(names)
+			}
+
+			export := exportFile{Profiles: make(map[string]exportedProfile, len(names))}
+			for _, name := range names {
+				e, ok := cfgs.Configurations[name]
+				if !ok {
+					return fmt.Errorf("profile %q not found", name)
+				}
+				p := exportedProfile{EcomConfigEntry: e}
+				if includeTokens {
+					tokenName, err := configmgr.TokenName(&e)
+					if err != nil {
+						return err
+					}
+					if p.Token, err = configmgr.ReadTokenAndRefreshToken(tokenName); err != nil {
+						return fmt.Errorf("read token of profile %q failed: %w", name, err)
+					}
+				}
+				export.Profiles[name] = p
+			}
+
+			data, err := yaml.Marshal(&export)
+			if err != nil {
+				return fmt.Errorf("marshal profiles failed: %w", err)
+			}
+			if file == "" {
+				_, err = f.IOStreams.Out.Write(data)
+				return err
+			}
+			if err := ioutil.WriteFile(file, data, 0600); err != nil {
+				return fmt.Errorf("write %q failed: %w", file, err)
+			}
+			fmt.Fprintf(f.IOStreams.ErrOut, "Profiles %s exported to %s.\n", strings.Join(names, ", "), file)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "file to write to (default stdout)")
+	cmd.Flags().BoolVar(&includeTokens, "include-tokens", false,
+		"include each profile's token and refresh token")
+	return cmd
+}
@@ -0,0 +1,87 @@
+package profiles
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// NewCmdProfilesImport returns new initialized instance of import sub command
+func NewCmdProfilesImport(f *cmdutil.Factory) *cobra.Command {
+	var overwrite bool
+	var cmd = &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import profiles written by profiles export",
+		Long: `Import the profiles in a file written by profiles export. Use - to read
+from stdin. Profiles exported without tokens sign in with their
+developer key on first use.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var data []byte
+			var err error
+			if args[0] == "-" {
+				data, err = ioutil.ReadAll(f.IOStreams.In)
+			} else {
+				data, err = ioutil.ReadFile(args[0])
+			}
+			if err != nil {
+				return fmt.Errorf("read %q failed: %w", args[0], err)
+			}
+			var imp exportFile
+			if err := yaml.UnmarshalStrict(data, &imp); err != nil {
+				return fmt.Errorf("parse %q failed: %w", args[0], err)
+			}
+			if len(imp.Profiles) == 0 {
+				return fmt.Errorf("no profiles in %q", args[0])
+			}
+			names := make([]string, 0, len(imp.Profiles))
+			for name, p := range imp.Profiles {
+				if _, err := configmgr.TokenName(&p.EcomConfigEntry); err != nil {
+					return fmt.Errorf("profile %q: %w", name, err)
+				}
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			err = configmgr.UpdateConfig(func(cfgs *configmgr.EcomConfigurations) error {
+				for _, name := range names {
+					if _, ok := cfgs.Configurations[name]; ok && !overwrite {
+						return fmt.Errorf("profile %q already exists; use --overwrite to replace it", name)
+					}
+				}
+				for _, name := range names {
+					cfgs.Configurations[name] = imp.Profiles[name].EcomConfigEntry
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				p := imp.Profiles[name]
+				if p.Token != nil {
+					tokenName, err := configmgr.TokenName(&p.EcomConfigEntry)
+					if err != nil {
+						return err
+					}
+					if err := configmgr.WriteTokenAndRefreshToken(tokenName, p.Token); err != nil {
+						return fmt.Errorf("write token of profile %q failed: %w", name, err)
+					}
+				}
+				fmt.Fprintf(f.IOStreams.Out, "Profile %q imported.\n", name)
+				if p.Token == nil && p.DevKey == "" {
+					fmt.Fprintf(f.IOStreams.ErrOut, "Warn: profile %q has no developer key or token; use ecom login to sign in again\n", name)
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "replace existing profiles of the same name")
+	return cmd
+}
@@ -2,44 +2,78 @@ package profiles
 
 import (
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"sort"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdProfilesList returns new initialized instance of list sub command
-func NewCmdProfilesList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdProfilesList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "Display a list of available profiles",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgs, curCfg, err := f.Config()
+			if err != nil {
+				return err
+			}
 			if len(cfgs.Configurations) == 0 {
-				fmt.Println("No profiles")
-				os.Exit(0)
+				fmt.Fprintln(f.IOStreams.Out, "No profiles")
+				return nil
 			}
-			format := "%v\t%v\t%v\t%v\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Active", "Endpoint", "Email", "Role", "Dev Key")
-			fmt.Fprintf(tw, format, "------", "--------", "-----", "----", "-------")
-			for k, v := range cfgs.Configurations {
+			// dev keys are masked, so the JSON and YAML formats print
+			// the same view as the table
+			type profile struct {
+				Name        string `json:"name"`
+				Active      bool   `json:"active"`
+				Endpoint    string `json:"endpoint"`
+				Environment string `json:"environment"`
+				Email       string `json:"email"`
+				Role        string `json:"role"`
+				DevKey      string `json:"developer_key"`
+			}
+			names := make([]string, 0, len(cfgs.Configurations))
+			for k := range cfgs.Configurations {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+
+			profiles := make([]profile, 0, len(names))
+			t := output.NewTable("Active", "Endpoint", "Environment", "Email", "Role", "Dev Key").Wide("Name")
+			for _, k := range names {
+				v := cfgs.Configurations[k]
+				p := profile{
+					Name:        k,
+					Active:      k == curCfg,
+					Endpoint:    v.Endpoint,
+					Environment: v.Environment,
+					Email:       v.Customer.Email,
+					Role:        v.Customer.Role,
+					DevKey:      maskDevKey(v.DevKey),
+				}
+				profiles = append(profiles, p)
+
 				var active string
-				if curCfg == k {
+				if p.Active {
 					active = "  *"
-				} else {
-					active = ""
 				}
-				fmt.Fprintf(tw, format, active, v.Endpoint, v.Customer.Email, v.Customer.Role, v.DevKey[0:5]+"********")
+				t.Row(active, p.Endpoint, p.Environment, p.Email, p.Role, p.DevKey, p.Name)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, profiles, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
+
+// maskDevKey returns the first few characters of a developer key, enough
+// to tell keys apart.
+func maskDevKey(key string) string {
+	if len(key) < 5 {
+		return ""
+	}
+	return key[:5] + "********"
+}
@@ -1,31 +1,29 @@
 package profiles
 
 import (
-	"errors"
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdProfilesRemove returns new initialized instance of remove sub command
-func NewCmdProfilesRemove() *cobra.Command {
-	cfgs, _, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdProfilesRemove(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "remove",
 		Short: "Remove a profile",
 		Long:  `Removes a profile dropping the credentials`,
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgs, _, err := f.Config()
+			if err != nil {
+				return err
+			}
 			if len(cfgs.Configurations) == 0 {
-				fmt.Println("No profiles")
-				os.Exit(0)
+				fmt.Fprintln(f.IOStreams.Out, "No profiles")
+				return nil
 			}
 			// build a slice of "Name (Endpoint)" strings
 			pl := make([]string, 0, 8)
@@ -35,31 +33,34 @@ func NewCmdProfilesRemove() *cobra.Command {
 
 			sel := promptSelectProfile(pl)
 			name := sel[:strings.Index(sel, "(")-1]
-			fmt.Fprintf(os.Stdout, "Profile %q selected.\n", name)
+			fmt.Fprintf(f.IOStreams.Out, "Profile %q selected.\n", name)
 
 			remove := confirm(fmt.Sprintf("Are you sure you want to remove %q", name))
 			if remove {
 				p := cfgs.Configurations[name]
-				hostname, err := configmgr.URLToHostName(p.Endpoint)
-				filename := fmt.Sprintf("%s-%s", hostname, p.DevKey[:6])
-				ok, err := configmgr.DeleteProject(filename)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Warn: remove profile failed: %+v\n", errors.Unwrap(err))
+				filename, err := configmgr.TokenName(&p)
+				if err == nil {
+					_, err = configmgr.DeleteProject(filename)
 				}
-				if !ok {
-					fmt.Fprintf(os.Stderr, "Warn: remove profile failed: %+v\n", err)
+				if err != nil {
+					fmt.Fprintf(f.IOStreams.ErrOut, "Warn: remove profile token failed: %v\n", err)
 				}
 				// delete the configuration and write the new config to the filesystem.
-				delete(cfgs.Configurations, name)
-				if err = configmgr.WriteConfig(cfgs); err != nil {
-					fmt.Fprintf(os.Stderr, "write config failed: %+v", err)
-					os.Exit(1)
+				err = configmgr.UpdateConfig(func(cfgs *configmgr.EcomConfigurations) error {
+					delete(cfgs.Configurations, name)
+					return nil
+				})
+				if err != nil {
+					return fmt.Errorf("write config failed: %w", err)
+				}
+				if err := configmgr.DeleteDevKey(name); err != nil {
+					fmt.Fprintf(f.IOStreams.ErrOut, "Warn: remove developer key failed: %v\n", err)
 				}
-				fmt.Fprintf(os.Stdout, "Project %q removed.\n", name)
-				os.Exit(0)
+				fmt.Fprintf(f.IOStreams.Out, "Project %q removed.\n", name)
+				return nil
 			}
-			fmt.Fprintf(os.Stdout, "Skipping removal\n")
-			os.Exit(0)
+			fmt.Fprintf(f.IOStreams.Out, "Skipping removal\n")
+			return nil
 		},
 	}
 	return cmd
@@ -0,0 +1,26 @@
+package profiles
+
+import (
+	"fmt"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdProfilesRename returns new initialized instance of rename sub command
+func NewCmdProfilesRename(f *cmdutil.Factory) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "rename <name> <new-name>",
+		Short: "Rename a profile",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := configmgr.RenameProfile(args[0], args[1]); err != nil {
+				return err
+			}
+			fmt.Fprintf(f.IOStreams.Out, "Profile %q renamed to %q.\n", args[0], args[1])
+			return nil
+		},
+	}
+	return cmd
+}
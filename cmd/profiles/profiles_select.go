@@ -2,25 +2,24 @@ package profiles
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdProfilesSelect returns new initialized instance of select sub command
-func NewCmdProfilesSelect() *cobra.Command {
-	cfgs, _, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdProfilesSelect(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "select",
 		Short: "Select and change to a new profile",
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgs, _, err := f.Config()
+			if err != nil {
+				return err
+			}
 			// build a slice of "Name (Endpoint)" strings
 			pl := make([]string, 0, 8)
 			for k, v := range cfgs.Configurations {
@@ -28,11 +27,11 @@ func NewCmdProfilesSelect() *cobra.Command {
 			}
 			sel := promptSelectProfile(pl)
 			name := sel[:strings.Index(sel, "(")-1]
-			fmt.Fprintf(os.Stdout, "Profile %q selected.\n", name)
+			fmt.Fprintf(f.IOStreams.Out, "Profile %q selected.\n", name)
 			if err := configmgr.WriteCurrentProject(name); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
 	return cmd
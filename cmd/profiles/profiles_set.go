@@ -0,0 +1,108 @@
+package profiles
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/spf13/cobra"
+)
+
+// profileSettings are the keys accepted by profiles set, each with a
+// function setting the value in a profile. An empty value clears the
+// setting.
+var profileSettings = map[string]func(e *configmgr.EcomConfigEntry, value string) error{
+	"environment": func(e *configmgr.EcomConfigEntry, value string) error {
+		e.Environment = value
+		return nil
+	},
+	"timezone":    setPreference(func(p *configmgr.Preferences) *string { return &p.Timezone }),
+	"time-format": setPreference(func(p *configmgr.Preferences) *string { return &p.TimeFormat }),
+	"locale":      setPreference(func(p *configmgr.Preferences) *string { return &p.Locale }),
+	"currency":    setPreference(func(p *configmgr.Preferences) *string { return &p.Currency }),
+	"price-list":  setPreference(func(p *configmgr.Preferences) *string { return &p.PriceList }),
+	"cart": func(e *configmgr.EcomConfigEntry, value string) error {
+		if value != "" && !cmdvalidate.IsValidUUID(value) {
+			return fmt.Errorf("cart %q is not a valid v4 uuid", value)
+		}
+		e.Preferences.Cart = value
+		return nil
+	},
+}
+
+// setPreference returns a function setting the preference field selects
+// after checking the display package accepts the new value.
+func setPreference(field func(p *configmgr.Preferences) *string) func(e *configmgr.EcomConfigEntry, value string) error {
+	return func(e *configmgr.EcomConfigEntry, value string) error {
+		p := e.Preferences
+		*field(&p) = value
+		if err := display.Validate(p); err != nil {
+			return err
+		}
+		e.Preferences = p
+		return nil
+	}
+}
+
+func profileSettingKeys() []string {
+	keys := make([]string, 0, len(profileSettings))
+	for k := range profileSettings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NewCmdProfilesSet returns new initialized instance of set sub command
+func NewCmdProfilesSet(f *cmdutil.Factory) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "set <name> <key> <value>",
+		Short: "Change a setting of a profile",
+		Long: `Change a setting of a profile. An empty value clears the setting.
+
+Keys:
+  environment  tags the profile, for example production; destructive
+               commands ask for the profile name to be typed before
+               changing a production profile, unless --yes is given
+  timezone     IANA time zone times are shown in (default Europe/London)
+  time-format  Go layout times are shown with (default 2006-01-02 15:04)
+  locale       separators and currency symbol placement of prices, one
+               of en-GB (default), en-IE, en-US, de-DE, es-ES, fr-FR,
+               it-IT or nl-NL
+  currency     ISO 4217 code of prices whose currency is not known
+               (default GBP)
+  price-list   price list code used by pricelists get and prices list
+  cart         cart used by the carts commands and orders create when
+               ECOM_CLI_CART_ID is not set`,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name, key, value := args[0], args[1], args[2]
+			set, ok := profileSettings[key]
+			if !ok {
+				return fmt.Errorf("unknown key %q (want one of %s)", key,
+					strings.Join(profileSettingKeys(), ", "))
+			}
+			err := configmgr.UpdateConfig(func(cfgs *configmgr.EcomConfigurations) error {
+				e, ok := cfgs.Configurations[name]
+				if !ok {
+					return fmt.Errorf("profile %q not found", name)
+				}
+				if err := set(&e, value); err != nil {
+					return err
+				}
+				cfgs.Configurations[name] = e
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(f.IOStreams.Out, "Profile %q: %s set to %q.\n", name, key, value)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,135 @@
+package profiles
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdProfilesShow returns new initialized instance of show sub command
+func NewCmdProfilesShow(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
+	var cmd = &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show a profile",
+		Long:  `Show the named profile, or the selected profile, including when its token expires.`,
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfgs, curCfg, err := f.Config()
+			if err != nil {
+				return err
+			}
+			name := curCfg
+			if len(args) > 0 {
+				name = args[0]
+			}
+			if name == "" {
+				return fmt.Errorf("no profile selected; use ecom profiles create or ecom profiles select")
+			}
+			e, ok := cfgs.Configurations[name]
+			if !ok {
+				return fmt.Errorf("profile %q not found", name)
+			}
+
+			type profile struct {
+				Name          string     `json:"name"`
+				Active        bool       `json:"active"`
+				Endpoint      string     `json:"endpoint"`
+				Environment   string     `json:"environment"`
+				UserID        string     `json:"user_id"`
+				UID           string     `json:"uid"`
+				Email         string     `json:"email"`
+				Role          string     `json:"role"`
+				Firstname     string     `json:"firstname"`
+				Lastname      string     `json:"lastname"`
+				DevKey        string     `json:"developer_key"`
+				Token         string     `json:"token"`
+				TokenExpires  *time.Time `json:"token_expires,omitempty"`
+				TokenProblems string     `json:"token_problems,omitempty"`
+
+				Preferences configmgr.Preferences `json:"preferences"`
+			}
+			p := profile{
+				Name:        name,
+				Active:      name == curCfg,
+				Endpoint:    e.Endpoint,
+				Environment: e.Environment,
+				UserID:      e.Customer.ID,
+				UID:         e.Customer.UID,
+				Email:       e.Customer.Email,
+				Role:        e.Customer.Role,
+				Firstname:   e.Customer.Firstname,
+				Lastname:    e.Customer.Lastname,
+				DevKey:      maskDevKey(e.DevKey),
+				Preferences: e.Preferences,
+			}
+			p.Token, p.TokenExpires, err = tokenExpiry(&e)
+			if err != nil {
+				p.TokenProblems = err.Error()
+			}
+
+			var expires string
+			if p.TokenExpires != nil {
+				expires = display.Time(*p.TokenExpires)
+				if p.TokenExpires.Before(time.Now()) {
+					expires += " (expired; refreshed on next use)"
+				}
+			} else {
+				expires = p.TokenProblems
+			}
+			t := output.NewRecord("Name", "Active", "Endpoint", "Environment",
+				"User ID", "UID", "Email", "Role", "User", "Dev Key", "Token",
+				"Token Expires", "Preferences")
+			t.Row(p.Name, p.Active, p.Endpoint, p.Environment, p.UserID, p.UID,
+				p.Email, p.Role, strings.TrimSpace(p.Firstname+" "+p.Lastname),
+				p.DevKey, p.Token, expires, preferences(&e.Preferences))
+			return out.Print(f.IOStreams.Out, &p, t)
+		},
+	}
+	output.AddFlag(cmd, &out)
+	return cmd
+}
+
+// tokenExpiry returns the name of the profile's token in the credential
+// store and when the token expires.
+func tokenExpiry(e *configmgr.EcomConfigEntry) (name string, expires *time.Time, err error) {
+	name, err = configmgr.TokenName(e)
+	if err != nil {
+		return "", nil, err
+	}
+	tar, err := configmgr.ReadTokenAndRefreshToken(name)
+	if err != nil {
+		return name, nil, err
+	}
+	claims, err := eclient.ParseTokenClaims(tar.IDToken)
+	if err != nil {
+		return name, nil, err
+	}
+	t := claims.ExpiresAt.In(display.Location())
+	return name, &t, nil
+}
+
+// preferences lists the preferences that are set as key=value pairs.
+func preferences(p *configmgr.Preferences) string {
+	var s []string
+	for _, v := range []struct{ key, value string }{
+		{"timezone", p.Timezone},
+		{"time-format", p.TimeFormat},
+		{"locale", p.Locale},
+		{"currency", p.Currency},
+		{"price-list", p.PriceList},
+		{"cart", p.Cart},
+	} {
+		if v.value != "" {
+			s = append(s, v.key+"="+v.value)
+		}
+	}
+	return strings.Join(s, " ")
+}
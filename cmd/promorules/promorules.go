@@ -1,18 +1,19 @@
 package promorules
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPromoRules returns new initialized instance of promorules sub command
-func NewCmdPromoRules() *cobra.Command {
+func NewCmdPromoRules(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "promorules",
 		Short: "Promotion Rules Management",
 	}
-	cmd.AddCommand(NewCmdPromoRulesCreate())
-	cmd.AddCommand(NewCmdPromoRulesGet())
-	cmd.AddCommand(NewCmdPromoRulesList())
-	cmd.AddCommand(NewCmdPromoRulesDelete())
+	cmd.AddCommand(NewCmdPromoRulesCreate(f))
+	cmd.AddCommand(NewCmdPromoRulesGet(f))
+	cmd.AddCommand(NewCmdPromoRulesList(f))
+	cmd.AddCommand(NewCmdPromoRulesDelete(f))
 	return cmd
 }
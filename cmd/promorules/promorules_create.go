@@ -4,48 +4,39 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
 	"time"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdPromoRulesCreate returns new initialized instance of create sub command
-func NewCmdPromoRulesCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPromoRulesCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a new promo rule",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
 			req, err := promptCreatePromoRule(ctx, client)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			promoRule, err := client.CreatePromoRule(ctx, req)
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				fmt.Fprintf(os.Stderr, "error creating promo rule: %+v\n", errors.Unwrap(err))
-				os.Exit(1)
+				return fmt.Errorf("error creating promo rule: %w", err)
 			}
-			showPromoRule(promoRule)
+			showPromoRule(f.IOStreams.Out, promoRule)
+			return nil
 		},
 	}
 	return cmd
@@ -89,7 +80,7 @@ func promptCreatePromoRule(ctx context.Context, client *eclient.EcomClient) (*ec
 				ts = ts + " 23:59:59"
 
 				var err error
-				startAtT, err = time.Parse("02/01/2006 15:04:05", ts)
+				startAtT, err = time.ParseInLocation("02/01/2006 15:04:05", ts, display.Location())
 				if err != nil {
 					return err
 				}
@@ -113,7 +104,7 @@ func promptCreatePromoRule(ctx context.Context, client *eclient.EcomClient) (*ec
 				ts = ts + " 00:00:00"
 
 				var err error
-				endAtT, err = time.Parse("02/01/2006 15:04:05", ts)
+				endAtT, err = time.ParseInLocation("02/01/2006 15:04:05", ts, display.Location())
 				if err != nil {
 					return err
 				}
@@ -193,7 +184,7 @@ func promptCreatePromoRule(ctx context.Context, client *eclient.EcomClient) (*ec
 		req.ProductID = productMap[sku]
 	case "category":
 		// build a list of categories
-		categories, err := client.GetCategories()
+		categories, err := client.GetCategories(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("%w: eclient.GetCategories() failed", err)
 		}
@@ -220,8 +211,7 @@ func promptCreatePromoRule(ctx context.Context, client *eclient.EcomClient) (*ec
 			return nil, fmt.Errorf("%w: client.GetShippingTariffs(ctx) failed", err)
 		}
 		if len(tariffs) < 1 {
-			fmt.Fprintf(os.Stderr, "No shipping tariffs have been created.\n")
-			os.Exit(1)
+			return nil, errors.New("no shipping tariffs have been created")
 		}
 
 		tariffMap := make(map[string]string, 0)
@@ -1,41 +1,33 @@
 package promorules
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdPromoRulesDelete returns new initialized instance of the delete sub command
-func NewCmdPromoRulesDelete() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPromoRulesDelete(f *cmdutil.Factory) *cobra.Command {
+	var yes bool
 	var cmd = &cobra.Command{
 		Use:   "delete <promo_rule_code>",
 		Short: "Delete a promo rule",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// promo_rule_code to id
 			promoRuleCode := args[0]
-			ctx := context.Background()
 			promoRules, err := client.GetPromoRules(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var promoRuleID string
 			for _, pr := range promoRules {
@@ -45,24 +37,25 @@ func NewCmdPromoRulesDelete() *cobra.Command {
 				}
 			}
 			if promoRuleID == "" {
-				fmt.Fprintf(os.Stderr, "promo_rule_code %q not found\n", promoRuleCode)
-				os.Exit(1)
+				return fmt.Errorf("promo_rule_code %q not found", promoRuleCode)
 			}
 
+			if err := f.Confirm(yes, "delete promo rule %q (%s)", promoRuleCode, promoRuleID); err != nil {
+				return err
+			}
 			err = client.DeletePromoRule(ctx, promoRuleID)
-			if err == eclient.ErrBadRequest {
-				fmt.Fprintf(os.Stderr, "Bad request - this is likely an error with the command line tool - please report this\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrBadRequest) {
+				return fmt.Errorf("bad request - this is likely an error with the command line tool - please report this")
 			}
-			if err == eclient.ErrPromoRuleNotFound {
-				fmt.Fprintf(os.Stderr, "Promo rule not found. Use ecom promorules list to check.\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPromoRuleNotFound) {
+				return fmt.Errorf("promo rule not found. Use ecom promorules list to check.")
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
+			return nil
 		},
 	}
+	cmdutil.AddYesFlag(cmd, &yes)
 	return cmd
 }
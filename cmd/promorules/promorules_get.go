@@ -1,12 +1,13 @@
 package promorules
 
 import (
-	"context"
+	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"io"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
@@ -16,31 +17,24 @@ type productResponseContainer struct {
 }
 
 // NewCmdPromoRulesGet returns new initialized instance of the get sub command
-func NewCmdPromoRulesGet() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdPromoRulesGet(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "get <promo_rule_code>",
 		Short: "Get promo rule",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// promo_rule_code to id
 			promoRuleCode := args[0]
-			ctx := context.Background()
 			promoRules, err := client.GetPromoRules(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 			var promoRuleID string
 			for _, pr := range promoRules {
@@ -50,74 +44,67 @@ func NewCmdPromoRulesGet() *cobra.Command {
 				}
 			}
 			if promoRuleID == "" {
-				fmt.Fprintf(os.Stderr, "promo_rule_code %q not found\n", promoRuleCode)
-				os.Exit(1)
+				return fmt.Errorf("promo_rule_code %q not found", promoRuleCode)
 			}
 
 			promoRule, err := client.GetPromoRule(ctx, promoRuleID)
-			if err == eclient.ErrPromoRuleNotFound {
-				fmt.Fprintf(os.Stderr, "promo rule %q (%q) not found\n", promoRuleCode, promoRuleID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrPromoRuleNotFound) {
+				return fmt.Errorf("promo rule %q (%q) not found", promoRuleCode, promoRuleID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			showPromoRule(promoRule)
+			return out.Print(f.IOStreams.Out, promoRule, promoRuleRecord(promoRule))
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
 
-func showPromoRule(promoRule *eclient.PromoRule) {
-	format := "%v\t%v\t\n"
-	tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-	fmt.Fprintf(tw, format, "Product Rule ID:", promoRule.ID)
-	fmt.Fprintf(tw, format, "Promo Rule Code:", promoRule.PromoRuleCode)
-	fmt.Fprintf(tw, format, "Name:", promoRule.Name)
-	fmt.Fprintf(tw, format, "Type:", promoRule.Type)
-	if promoRule.Type == "percentage" {
-		fmt.Fprintf(tw, "%v\t%.2f%%\t\n", "Amount:", float64(promoRule.Amount)/100.0)
-	} else {
-		fmt.Fprintf(tw, "%v\t£%.4f\t\n", "Amount", float64(promoRule.Amount)/10000.0)
-	}
+func showPromoRule(w io.Writer, v *eclient.PromoRule) {
+	promoRuleRecord(v).Write(w)
+}
 
-	var startAt, endAt string
-	if promoRule.StartAt == nil {
-		startAt = "-"
-	} else {
-		startAt = promoRule.StartAt.In(location).Format(timeDisplayFormat)
-	}
-	if promoRule.EndAt == nil {
-		endAt = "-"
-	} else {
-		endAt = promoRule.EndAt.In(location).Format(timeDisplayFormat)
-	}
-	fmt.Fprintf(tw, format, "Start At:", startAt)
-	fmt.Fprintf(tw, format, "End At:", endAt)
-	fmt.Fprintf(tw, format, "Target:", promoRule.Target)
-	switch promoRule.Target {
+func promoRuleRecord(v *eclient.PromoRule) *output.Table {
+	headers := []string{"Promo Rule ID", "Promo Rule Code", "Name", "Type",
+		"Amount", "Start At", "End At", "Target"}
+	cells := []interface{}{v.ID, v.PromoRuleCode, v.Name, v.Type,
+		promoRuleAmount(v), v.StartAt, v.EndAt, v.Target}
+	switch v.Target {
 	case "product":
-		fmt.Fprintf(tw, format, "Product ID:", *promoRule.ProductID)
-		fmt.Fprintf(tw, format, "Product Path:", *promoRule.ProductPath)
-		fmt.Fprintf(tw, format, "Product SKU:", *promoRule.ProductSKU)
+		headers = append(headers, "Product ID", "Product Path", "Product SKU")
+		cells = append(cells, v.ProductID, v.ProductPath, v.ProductSKU)
 	case "productset":
-		fmt.Fprintf(tw, format, "Product Set ID", *promoRule.ProductSetID)
+		headers = append(headers, "Product Set ID")
+		cells = append(cells, v.ProductSetID)
 	case "category":
-		fmt.Fprintf(tw, format, "Category ID:", *promoRule.CategoryID)
-		fmt.Fprintf(tw, format, "Category Path:", *promoRule.CategoryPath)
+		headers = append(headers, "Category ID", "Category Path")
+		cells = append(cells, v.CategoryID, v.CategoryPath)
 	case "shipping_tariff":
-		fmt.Fprintf(tw, format, "Shipping Tariff ID:", *promoRule.ShippingTariffID)
-		fmt.Fprintf(tw, format, "Shipping Tariff Code:", *promoRule.ShippingTariffCode)
+		headers = append(headers, "Shipping Tariff ID", "Shipping Tariff Code")
+		cells = append(cells, v.ShippingTariffID, v.ShippingTariffCode)
 	case "total":
-		fmt.Fprintf(tw, "%v\t£%.2f\t\n", "Total Threshold",
-			float64(*promoRule.TotalThreshold)/10000.0)
-	default:
-		fmt.Fprintf(os.Stderr, "unknown promo rule target %q\n", promoRule.Target)
-		os.Exit(1)
+		var threshold string
+		if v.TotalThreshold != nil {
+			threshold = display.Price(*v.TotalThreshold, "")
+		}
+		headers = append(headers, "Total Threshold")
+		cells = append(cells, threshold)
+	}
+	headers = append(headers, "Created", "Modified")
+	cells = append(cells, v.Created, v.Modified)
+
+	t := output.NewRecord(headers...)
+	t.Row(cells...)
+	return t
+}
+
+// promoRuleAmount returns the amount as a percentage or a price depending
+// on the type of promo rule.
+func promoRuleAmount(v *eclient.PromoRule) string {
+	if v.Type == "percentage" {
+		return fmt.Sprintf("%.2f%%", float64(v.Amount)/100.0)
 	}
-	fmt.Fprintf(tw, format, "Created:", promoRule.Created.In(location).Format(timeDisplayFormat))
-	fmt.Fprintf(tw, format, "Modified:", promoRule.Modified.In(location).Format(timeDisplayFormat))
-	tw.Flush()
+	return display.Price(v.Amount, "")
 }
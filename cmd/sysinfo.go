@@ -2,39 +2,40 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 	"text/tabwriter"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdSysInfo returns new initialized instance of the sysinfo sub command
-func NewCmdSysInfo() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdSysInfo(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "sysinfo",
 		Short: "Prints system information from the running API service.",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			ecomClient := eclient.New(current.Endpoint)
-			if err := ecomClient.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			ecomClient, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+			current, err := f.Profile()
+			if err != nil {
+				return err
 			}
-			sysInfo, err := ecomClient.SysInfo()
+			sysInfo, err := ecomClient.SysInfo(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
+			}
+
+			if !out.Human() {
+				return out.Print(f.IOStreams.Out, sysInfo, nil)
 			}
 
 			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 
 			fmt.Fprintf(tw, format, "Ecom CLI Tool", "")
 			fmt.Fprintf(tw, format, "-------------", "")
@@ -76,7 +77,9 @@ func NewCmdSysInfo() *cobra.Command {
 			fmt.Fprintf(tw, "%v\t%t\t\n", "Stackdriver Logging Enabled:", sysInfo.Env.App.AppEnableStackDriverLogging)
 			fmt.Fprintf(tw, format, "Endpoint:", sysInfo.Env.App.AppEndpoint)
 			tw.Flush()
+			return nil
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
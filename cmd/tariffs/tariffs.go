@@ -1,16 +1,17 @@
 package tariffs
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdShippingTariffsRules returns new initialized instance of tariffs sub command
-func NewCmdShippingTariffsRules() *cobra.Command {
+func NewCmdShippingTariffsRules(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "tariffs",
 		Short: "Shipping Tariff Management",
 	}
-	cmd.AddCommand(NewCmdShippingTariffsList())
-	cmd.AddCommand(NewCmdShippingTarrifsCreate())
+	cmd.AddCommand(NewCmdShippingTariffsList(f))
+	cmd.AddCommand(NewCmdShippingTarrifsCreate(f))
 	return cmd
 }
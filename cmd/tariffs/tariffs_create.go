@@ -1,14 +1,13 @@
 package tariffs
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strconv"
 	"text/tabwriter"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/ecommerce-builder/ecom-cli-tool/service"
 	"github.com/spf13/cobra"
@@ -16,48 +15,40 @@ import (
 )
 
 // NewCmdShippingTarrifsCreate returns new initialized instance of create sub command
-func NewCmdShippingTarrifsCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdShippingTarrifsCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a new shipping tariff",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// get the request params
 			req, err := promptCreateShippingTariff()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			ctx := context.Background()
 			tariff, err := client.CreateShippingTariff(ctx, req)
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 			fmt.Fprintf(tw, format, "Shipping Tariff ID:", tariff.ID)
 			fmt.Fprintf(tw, format, "Country Code:", tariff.CountryCode)
 			fmt.Fprintf(tw, format, "Shipping Code:", tariff.ShippingCode)
 			fmt.Fprintf(tw, format, "Name:", tariff.Name)
-			fmt.Fprintf(tw, format, "Price:", tariff.Price)
+			fmt.Fprintf(tw, format, "Price:", display.Price(tariff.Price, ""))
 			fmt.Fprintf(tw, format, "Tax Code:", tariff.TaxCode)
-			fmt.Fprintf(tw, format, "Created:", tariff.Created.In(location).Format(timeDisplayFormat))
-			fmt.Fprintf(tw, format, "Modified:", tariff.Modified.In(location).Format(timeDisplayFormat))
+			fmt.Fprintf(tw, format, "Created:", display.Time(tariff.Created))
+			fmt.Fprintf(tw, format, "Modified:", display.Time(tariff.Modified))
 			tw.Flush()
+			return nil
 		},
 	}
 	return cmd
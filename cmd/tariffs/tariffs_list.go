@@ -1,71 +1,40 @@
 package tariffs
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v", "Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdShippingTariffsList returns new initialized instance of the list sub command
-func NewCmdShippingTariffsList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdShippingTariffsList(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "list shipping tariffs",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			tariffs, err := client.GetShippingTariffs(context.TODO())
+			tariffs, err := client.GetShippingTariffs(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%s\t%s\t%v\t%s\t%v\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "Shipping Tariff ID", "Shipping Code", "Country Code",
-				"Name", "Price", "Tax code", "Created", "Modified")
-			fmt.Fprintf(tw, format, "------------------", "-------------", "------------",
-				"----", "-----", "--------", "-------", "--------")
-
-			for _, t := range tariffs {
-				fmt.Fprintf(tw, format,
-					t.ID, t.ShippingCode, t.CountryCode,
-					t.Name, t.Price, t.TaxCode,
-					t.Created.In(location).Format(timeDisplayFormat),
-					t.Modified.In(location).Format(timeDisplayFormat))
+			t := output.NewTable("Shipping Tariff ID", "Shipping Code",
+				"Country Code", "Name", "Price", "Tax code", "Created", "Modified")
+			for _, v := range tariffs {
+				t.Row(v.ID, v.ShippingCode, v.CountryCode, v.Name,
+					display.Price(v.Price, ""),
+					v.TaxCode, v.Created, v.Modified)
 			}
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, tariffs, t)
 		},
 	}
+	output.AddFlag(cmd, &out)
 	return cmd
 }
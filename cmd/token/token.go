@@ -1,15 +1,18 @@
 package token
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdToken returns new initialized instance of token sub command
-func NewCmdToken() *cobra.Command {
+func NewCmdToken(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "token",
 		Short: "Token management",
 	}
-	cmd.AddCommand(NewCmdTokenShow())
+	cmd.AddCommand(NewCmdTokenInspect(f))
+	cmd.AddCommand(NewCmdTokenRefresh(f))
+	cmd.AddCommand(NewCmdTokenShow(f))
 	return cmd
 }
@@ -0,0 +1,96 @@
+package token
+
+import (
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/spf13/cobra"
+)
+
+// tokenInfo is the decoded ID token as printed by token inspect.
+type tokenInfo struct {
+	UID       string    `json:"uid"`
+	Role      string    `json:"role"`
+	Email     string    `json:"email"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Remaining string    `json:"remaining"`
+	Expired   bool      `json:"expired"`
+}
+
+// NewCmdTokenInspect returns new initialized instance of inspect sub command
+func NewCmdTokenInspect(f *cmdutil.Factory) *cobra.Command {
+	var out output.Printer
+	var cmd = &cobra.Command{
+		Use:   "inspect",
+		Short: "Show the claims of the current JSON Web Token",
+		Long: `Decode the current Firebase ID token and show who it was issued to and
+when it expires. The token is shown as stored; use token refresh to
+replace an expired token.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			idToken, err := currentIDToken(f)
+			if err != nil {
+				return err
+			}
+			claims, err := eclient.ParseTokenClaims(idToken)
+			if err != nil {
+				return err
+			}
+
+			info := tokenInfo{
+				UID:       claims.UID,
+				Role:      claims.Role,
+				Email:     claims.Email,
+				IssuedAt:  claims.IssuedAt.In(display.Location()),
+				ExpiresAt: claims.ExpiresAt.In(display.Location()),
+			}
+			remaining := time.Until(claims.ExpiresAt).Truncate(time.Second)
+			if remaining > 0 {
+				info.Remaining = remaining.String()
+			} else {
+				info.Remaining = "expired"
+				info.Expired = true
+			}
+
+			t := output.NewRecord("UID", "Role", "Email", "Issued",
+				"Expires", "Remaining")
+			t.Row(info.UID, info.Role, info.Email,
+				display.Time(info.IssuedAt),
+				display.Time(info.ExpiresAt), info.Remaining)
+			return out.Print(f.IOStreams.Out, &info, t)
+		},
+	}
+	output.AddFlag(cmd, &out)
+	return cmd
+}
+
+// currentIDToken returns the stored ID token of the selected profile
+// without refreshing it. An ephemeral profile has no stored token, so
+// it is signed in instead.
+func currentIDToken(f *cmdutil.Factory) (string, error) {
+	if _, ok := configmgr.EphemeralProfile(); ok {
+		client, err := f.Client(cmdutil.Context())
+		if err != nil {
+			return "", err
+		}
+		return client.JWT(), nil
+	}
+	current, err := f.Profile()
+	if err != nil {
+		return "", err
+	}
+	name, err := configmgr.TokenName(current)
+	if err != nil {
+		return "", err
+	}
+	tar, err := configmgr.ReadTokenAndRefreshToken(name)
+	if err != nil {
+		return "", err
+	}
+	return tar.IDToken, nil
+}
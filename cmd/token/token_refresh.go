@@ -0,0 +1,38 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdTokenRefresh returns new initialized instance of refresh sub command
+func NewCmdTokenRefresh(f *cmdutil.Factory) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "refresh",
+		Short: "Exchange the refresh token for a new JSON Web Token",
+		Long: `Exchange the profile's refresh token for a new Firebase ID token, even
+if the current one has not expired, and save the new pair in ~/.ecom.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
+			}
+			if err := client.Refresh(ctx); err != nil {
+				return err
+			}
+			claims, err := client.Claims()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(f.IOStreams.Out, "Token refreshed; expires %s.\n",
+				display.Time(claims.ExpiresAt))
+			return nil
+		},
+	}
+	return cmd
+}
@@ -2,46 +2,25 @@ package token
 
 import (
 	"fmt"
-	"os"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
-	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdTokenShow returns new initialized instance of token sub command
-func NewCmdTokenShow() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
-
+func NewCmdTokenShow(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "show",
 		Short: "Show the current JSON Web Token",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
-			}
-
-			file, err := configmgr.TokenFilename(&current)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			// signing in refreshes the token if it has expired
+			client, err := f.Client(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "token file %q not found", file)
-				os.Exit(1)
+				return err
 			}
-
-			tar, err := configmgr.ReadTokenAndRefreshToken(file)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "tokenand refresh token cannot be read from %q: %v", file, err)
-				os.Exit(1)
-			}
-
-			fmt.Printf("%s\n", tar.IDToken)
-			os.Exit(0)
+			fmt.Fprintf(f.IOStreams.Out, "%s\n", client.JWT())
+			return nil
 		},
 	}
 	return cmd
@@ -1,16 +1,17 @@
 package users
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdUsers returns new initialized instance of the customers sub command
-func NewCmdUsers() *cobra.Command {
+func NewCmdUsers(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "users",
 		Short: "User management",
 	}
-	cmd.AddCommand(NewCmdUsersCreate())
-	cmd.AddCommand(NewCmdUsersList())
+	cmd.AddCommand(NewCmdUsersCreate(f))
+	cmd.AddCommand(NewCmdUsersList(f))
 	return cmd
 }
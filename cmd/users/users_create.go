@@ -1,50 +1,40 @@
 package users
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"text/tabwriter"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdUsersCreate returns new initialized instance of create sub command
-func NewCmdUsersCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdUsersCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a user",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			req, err := promptCreateUser(client)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			ctx := context.Background()
 			user, err := client.CreateUser(ctx, req)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "error creating user: %v\n", err.Error())
-				os.Exit(1)
+				return fmt.Errorf("error creating user: %w", err)
 			}
 
 			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 			fmt.Fprintf(tw, format, "User ID:", user.ID)
 			fmt.Fprintf(tw, format, "UID:", user.UID)
 			fmt.Fprintf(tw, format, "Role:", user.Role)
@@ -53,10 +43,11 @@ func NewCmdUsersCreate() *cobra.Command {
 			fmt.Fprintf(tw, format, "Firstname:", user.Firstname)
 			fmt.Fprintf(tw, format, "Lastname:", user.Lastname)
 			fmt.Fprintf(tw, format, "Created:",
-				user.Created.In(location).Format(timeDisplayFormat))
+				display.Time(user.Created))
 			fmt.Fprintf(tw, format, "Modified:",
-				user.Modified.In(location).Format(timeDisplayFormat))
+				display.Time(user.Modified))
 			tw.Flush()
+			return nil
 		},
 	}
 	return cmd
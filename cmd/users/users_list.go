@@ -1,69 +1,42 @@
 package users
 
 import (
-	"context"
-	"fmt"
-	"os"
-	"text/tabwriter"
-	"time"
-
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/output"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 )
 
-const timeDisplayFormat = "2006-01-02 15:04"
-
-var location *time.Location
-
-func init() {
-	var err error
-	location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v\n",
-			"Europe/London", err.Error())
-		return
-	}
-}
-
 // NewCmdUsersList returns new initialized instance of list sub command
-func NewCmdUsersList() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdUsersList(f *cmdutil.Factory) *cobra.Command {
+	var opts eclient.ListOptions
+	var out output.Printer
 	var cmd = &cobra.Command{
 		Use:   "list",
 		Short: "List users",
 		Long:  ``,
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
-			ctx := context.Background()
-			users, err := client.GetUsers(ctx)
+			users, err := client.Users(&opts).All(ctx)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
-			format := "%s\t%s\t%s\t%s\t%s\t%s\t%v\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
-			fmt.Fprintf(tw, format, "User ID", "UID", "Role", "Email", "Firstname", "Lastname", "Created")
-			fmt.Fprintf(tw, format, "-------", "---", "----", "-----", "---------", "--------", "-------")
-
-			for _, user := range users {
-				fmt.Fprintf(tw, format,
-					user.ID, user.UID, user.Role, user.Email, user.Firstname, user.Lastname, user.Created.In(location).Format(timeDisplayFormat))
+			t := output.NewTable("User ID", "UID", "Role", "Email", "Firstname",
+				"Lastname", "Created").Wide("Modified")
+			for _, v := range users {
+				t.Row(v.ID, v.UID, v.Role, v.Email, v.Firstname, v.Lastname,
+					v.Created, v.Modified)
 			}
-
-			tw.Flush()
+			return out.Print(f.IOStreams.Out, users, t)
 		},
 	}
+	cmdutil.AddListFlags(cmd, &opts)
+	output.AddFlag(cmd, &out)
 	return cmd
 }
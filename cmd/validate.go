@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdValidate returns new initialized instance of the validate sub command
+func NewCmdValidate(f *cmdutil.Factory) *cobra.Command {
+	var cmd = &cobra.Command{
+		Use:   "validate <file|dir>",
+		Short: "Check YAML input files without applying them",
+		Long: `Check a YAML input file, or each YAML file in a directory, as products
+apply, categories-tree apply, pcrelations apply and inventory
+batch-update would before making any API call. The kind of each file is
+told from its top-level key: product, catalog,
+product_category_relations or inventory.
+
+Unknown fields, values of the wrong type, missing required fields and
+values out of range are reported with their file and line number.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			files := []string{args[0]}
+			fi, err := os.Stat(args[0])
+			if err != nil {
+				return err
+			}
+			if fi.IsDir() {
+				if files, err = cmdutil.YAMLFiles(args[0]); err != nil {
+					return err
+				}
+			}
+
+			invalid := 0
+			for _, file := range files {
+				data, err := ioutil.ReadFile(file)
+				if err != nil {
+					return err
+				}
+				kind, err := cmdvalidate.ValidateYAML(file, data)
+				if err != nil {
+					invalid++
+					fmt.Fprintln(f.IOStreams.Out, err)
+					continue
+				}
+				fmt.Fprintf(f.IOStreams.Out, "%s: ok (%s)\n", file, kind)
+			}
+			if invalid > 0 {
+				return fmt.Errorf("%d of %d files are invalid", invalid, len(files))
+			}
+			return nil
+		},
+	}
+	return cmd
+}
@@ -2,8 +2,8 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
@@ -11,13 +11,13 @@ import (
 var Version string
 
 // NewCmdVersion returns new initialized instance of the version sub command
-func NewCmdVersion() *cobra.Command {
+func NewCmdVersion(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "version",
 		Short: "Displays the ecom version",
-		Run: func(cmd *cobra.Command, args []string) {
-			fmt.Fprintf(os.Stdout, "%s\n", Version)
-			os.Exit(0)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Fprintf(f.IOStreams.Out, "%s\n", Version)
+			return nil
 		},
 	}
 	return cmd
@@ -1,19 +1,20 @@
 package webhooks
 
 import (
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 // NewCmdWebhooks returns new initialized instance of the webhooks sub command
-func NewCmdWebhooks() *cobra.Command {
+func NewCmdWebhooks(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "webhooks",
 		Short: "Webhooks Management",
 	}
-	cmd.AddCommand(NewCmdWebhooksCreate())
-	cmd.AddCommand(NewCmdWebhooksList())
-	cmd.AddCommand(NewCmdWebhooksGet())
-	cmd.AddCommand(NewCmdWebhooksUpdate())
-	cmd.AddCommand(NewCmdWebhooksDelete())
+	cmd.AddCommand(NewCmdWebhooksCreate(f))
+	cmd.AddCommand(NewCmdWebhooksList(f))
+	cmd.AddCommand(NewCmdWebhooksGet(f))
+	cmd.AddCommand(NewCmdWebhooksUpdate(f))
+	cmd.AddCommand(NewCmdWebhooksDelete(f))
 	return cmd
 }
@@ -1,13 +1,12 @@
 package webhooks
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 	"text/tabwriter"
 
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
@@ -34,59 +33,46 @@ const (
 )
 
 // NewCmdWebhooksCreate returns new initialized instance of create sub command
-func NewCmdWebhooksCreate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdWebhooksCreate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "create",
 		Short: "Create a webhook",
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// get the url and event list
 			req, err := promptCreateWebhook()
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			// attempt to create the webhook
-			ctx := context.Background()
 			webhook, err := client.CreateWebhook(ctx, req)
-			if err == eclient.ErrEventTypeNotFound {
-				fmt.Fprint(os.Stderr, "one or more of the events are not known\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrEventTypeNotFound) {
+				return errors.New("one or more of the events are not known")
 			}
-			if err == eclient.ErrWebhookExists {
-				fmt.Fprintf(os.Stderr, "webhook with this URL of %s already exists\n",
-					req.URL)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrWebhookExists) {
+				return fmt.Errorf("webhook with this URL of %s already exists", req.URL)
 			}
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				fmt.Fprintf(os.Stderr, "error creating user: %+v\n",
-					errors.Unwrap(err))
-				os.Exit(1)
+				return fmt.Errorf("error creating webhook: %w", err)
 			}
 
 			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 			fmt.Fprintf(tw, format, "ID:", webhook.ID)
 			fmt.Fprintf(tw, format, "Signing Key:", webhook.SigningKey)
 			fmt.Fprintf(tw, format, "URL:", webhook.URL)
 			fmt.Fprintf(tw, format, "Events:", webhook.Events)
 			fmt.Fprintf(tw, format, "Enabled:", webhook.Enabled)
-			fmt.Fprintf(tw, format, "Created:", webhook.Created)
-			fmt.Fprintf(tw, format, "Modified:", webhook.Modified)
+			fmt.Fprintf(tw, format, "Created:", display.Time(webhook.Created))
+			fmt.Fprintf(tw, format, "Modified:", display.Time(webhook.Modified))
 			tw.Flush()
+			return nil
 		},
 	}
 	return cmd
@@ -1,88 +1,73 @@
 package webhooks
 
 import (
-	"context"
 	"errors"
 	"fmt"
-	"os"
 	"text/tabwriter"
 
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/display"
 	"github.com/ecommerce-builder/ecom-cli-tool/cmdvalidate"
-	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
 // NewCmdWebhooksUpdate returns new initialized instance of the update sub command
-func NewCmdWebhooksUpdate() *cobra.Command {
-	cfgs, curCfg, err := configmgr.GetCurrentConfig()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
-	}
+func NewCmdWebhooksUpdate(f *cmdutil.Factory) *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:   "update <webhook_id>",
 		Short: "Update a webhook",
 		Args:  cobra.ExactArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
-			current := cfgs.Configurations[curCfg]
-			client := eclient.New(current.Endpoint)
-			if err := client.SetToken(&current); err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmdutil.Context()
+			client, err := f.Client(ctx)
+			if err != nil {
+				return err
 			}
 
 			// webhook_id command parameter
 			webhookID := args[0]
 			if !cmdvalidate.IsValidUUID(webhookID) {
-				fmt.Fprintf(os.Stderr, "webhook_id must be a valid v4 uuid\n")
-				os.Exit(1)
+				return fmt.Errorf("webhook_id must be a valid v4 uuid")
 			}
 
-			ctx := context.Background()
 			existingWebhook, err := client.GetWebhook(ctx, webhookID)
-			if err == eclient.ErrWebhookNotFound {
-				fmt.Fprintf(os.Stderr, "webhook %s not found\n", webhookID)
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrWebhookNotFound) {
+				return fmt.Errorf("webhook %s not found", webhookID)
 			}
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			req, err := promptUpdateWebhook(existingWebhook.URL, existingWebhook.Events, existingWebhook.Enabled)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "%+v\n", err)
-				os.Exit(1)
+				return err
 			}
 
 			// attempt to create the webhook
 			webhook, err := client.UpdateWebhook(ctx, webhookID, req)
-			if err == eclient.ErrEventTypeNotFound {
-				fmt.Fprint(os.Stderr, "one or more of the events are not known\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrEventTypeNotFound) {
+				return errors.New("one or more of the events are not known")
 			}
-			if err == eclient.ErrWebhookExists {
-				fmt.Fprint(os.Stderr, "webhook with this URL already exists\n")
-				os.Exit(1)
+			if errors.Is(err, eclient.ErrWebhookExists) {
+				return errors.New("webhook with this URL already exists")
 			}
 			if err != nil {
-				fmt.Printf("%+v\n", err)
-				fmt.Fprintf(os.Stderr, "error creating user: %+v\n", errors.Unwrap(err))
-				os.Exit(1)
+				return fmt.Errorf("error updating webhook: %w", err)
 			}
 
 			format := "%v\t%v\t\n"
-			tw := new(tabwriter.Writer).Init(os.Stdout, 0, 8, 2, ' ', 0)
+			tw := new(tabwriter.Writer).Init(f.IOStreams.Out, 0, 8, 2, ' ', 0)
 			fmt.Fprintf(tw, format, "Webhook ID:", webhook.ID)
 			fmt.Fprintf(tw, format, "Signing Key:", webhook.SigningKey)
 			fmt.Fprintf(tw, format, "URL:", webhook.URL)
 			fmt.Fprintf(tw, format, "Events:", webhook.Events)
 			fmt.Fprintf(tw, format, "Enabled:", webhook.Enabled)
-			fmt.Fprintf(tw, format, "Created:", webhook.Created.In(location).Format(timeDisplayFormat))
-			fmt.Fprintf(tw, format, "Modified:", webhook.Modified.In(location).Format(timeDisplayFormat))
+			fmt.Fprintf(tw, format, "Created:", display.Time(webhook.Created))
+			fmt.Fprintf(tw, format, "Modified:", display.Time(webhook.Modified))
 			tw.Flush()
+			return nil
 		},
 	}
 	return cmd
@@ -0,0 +1,370 @@
+package cmdvalidate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"gopkg.in/yaml.v3"
+)
+
+// Error is a problem found in a YAML input file. Line is 0 if the
+// problem is with the file as a whole.
+type Error struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Errors are the problems found in a YAML input file, in the order
+// they appear.
+type Errors []*Error
+
+func (e Errors) Error() string {
+	s := make([]string, 0, len(e))
+	for _, err := range e {
+		s = append(s, err.Error())
+	}
+	return strings.Join(s, "\n")
+}
+
+// The kinds of YAML input file, named by their top-level key.
+const (
+	KindProduct                  = "product"
+	KindCatalog                  = "catalog"
+	KindProductCategoryRelations = "product_category_relations"
+	KindInventory                = "inventory"
+)
+
+// YAMLKind returns the kind of YAML input file data is, or an error if
+// it is not one.
+func YAMLKind(filename string, data []byte) (string, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return "", decodeErrors(filename, err)
+	}
+	n := document(&root)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return "", Errors{{File: filename, Message: "not a product, catalog, product category relations or inventory file"}}
+	}
+	for i := 0; i < len(n.Content); i += 2 {
+		switch k := n.Content[i].Value; k {
+		case KindProduct, KindCatalog, KindProductCategoryRelations, KindInventory:
+			return k, nil
+		}
+	}
+	return "", Errors{{File: filename, Line: n.Line,
+		Message: "not a product, catalog, product category relations or inventory file"}}
+}
+
+// ValidateYAML checks data, the contents of the YAML input file
+// filename, is valid for its kind.
+func ValidateYAML(filename string, data []byte) (kind string, err error) {
+	kind, err = YAMLKind(filename, data)
+	if err != nil {
+		return "", err
+	}
+	switch kind {
+	case KindProduct:
+		err = Product(filename, data)
+	case KindCatalog:
+		err = Catalog(filename, data)
+	case KindProductCategoryRelations:
+		err = ProductCategoryRelations(filename, data)
+	case KindInventory:
+		err = InventoryBatch(filename, data)
+	}
+	return kind, err
+}
+
+// Product checks data is a valid product file for products apply.
+func Product(filename string, data []byte) error {
+	var v eclient.ProductContainerYAML
+	root, errs := decodeStrict(filename, data, &v)
+	if root == nil {
+		return errs
+	}
+	c := checker{file: filename, errs: errs}
+	product := c.required(root, "product")
+	if product == nil {
+		return c.result()
+	}
+	c.nonEmpty(product, "sku")
+	c.nonEmpty(product, "path")
+	c.nonEmpty(product, "name")
+	if images := value(product, "images"); images != nil {
+		for _, image := range images.Content {
+			c.nonEmpty(image, "path")
+		}
+	}
+	if prices := value(product, "prices"); prices != nil && prices.Kind == yaml.MappingNode {
+		for i := 0; i < len(prices.Content); i += 2 {
+			code, list := prices.Content[i], prices.Content[i+1]
+			if code.Value == "" {
+				c.add(code, "empty price list code")
+			}
+			breaks := make(map[int]bool)
+			for _, price := range list.Content {
+				if b, ok := c.intAtLeast(price, "break", 1); ok {
+					if breaks[b] {
+						c.add(price, fmt.Sprintf("price list %s has more than one price for break %d", code.Value, b))
+					}
+					breaks[b] = true
+				}
+				c.intAtLeast(price, "unit_price", 0)
+			}
+		}
+	}
+	return c.result()
+}
+
+// Catalog checks data is a valid categories tree for categories-tree
+// apply.
+func Catalog(filename string, data []byte) error {
+	var v eclient.CatalogYAML
+	root, errs := decodeStrict(filename, data, &v)
+	if root == nil {
+		return errs
+	}
+	c := checker{file: filename, errs: errs}
+	if endpoints := value(root, "endpoints"); endpoints != nil {
+		for _, e := range endpoints.Content {
+			if e.Value == "" || strings.Contains(e.Value, "/") {
+				c.add(e, fmt.Sprintf("endpoint %q is not a host name", e.Value))
+			}
+		}
+	}
+	if catalog := c.required(root, "catalog"); catalog != nil {
+		c.category(catalog)
+	}
+	return c.result()
+}
+
+var segmentRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+func (c *checker) category(n *yaml.Node) {
+	if s, ok := c.nonEmpty(n, "segment"); ok && !segmentRegexp.MatchString(s) {
+		c.add(value(n, "segment"), fmt.Sprintf("segment %q must be lower case letters, digits, - and _", s))
+	}
+	c.nonEmpty(n, "name")
+	children := value(n, "categories")
+	if children == nil {
+		return
+	}
+	segments := make(map[string]bool)
+	for _, child := range children.Content {
+		if s := value(child, "segment"); s != nil && s.Value != "" {
+			if segments[s.Value] {
+				c.add(s, fmt.Sprintf("duplicate segment %q", s.Value))
+			}
+			segments[s.Value] = true
+		}
+		c.category(child)
+	}
+}
+
+// ProductCategoryRelations checks data is a valid product to category
+// relations file for pcrelations apply.
+func ProductCategoryRelations(filename string, data []byte) error {
+	var v eclient.ProductCategoryRelationsYAML
+	root, errs := decodeStrict(filename, data, &v)
+	if root == nil {
+		return errs
+	}
+	c := checker{file: filename, errs: errs}
+	rels := c.required(root, "product_category_relations")
+	if rels == nil || rels.Kind != yaml.MappingNode {
+		return c.result()
+	}
+	for i := 0; i < len(rels.Content); i += 2 {
+		path, set := rels.Content[i], rels.Content[i+1]
+		if path.Value == "" {
+			c.add(path, "empty category path")
+		}
+		products := value(set, "products")
+		if products == nil {
+			continue
+		}
+		skus := make(map[string]bool)
+		for _, sku := range products.Content {
+			switch {
+			case sku.Value == "":
+				c.add(sku, "empty sku")
+			case skus[sku.Value]:
+				c.add(sku, fmt.Sprintf("sku %s is listed more than once for %s", sku.Value, path.Value))
+			}
+			skus[sku.Value] = true
+		}
+	}
+	return c.result()
+}
+
+// InventoryBatch checks data is a valid inventory file for inventory
+// batch-update.
+func InventoryBatch(filename string, data []byte) error {
+	var v eclient.InventoryBatchContainerYAML
+	root, errs := decodeStrict(filename, data, &v)
+	if root == nil {
+		return errs
+	}
+	c := checker{file: filename, errs: errs}
+	inventory := c.required(root, "inventory")
+	if inventory == nil {
+		return c.result()
+	}
+	skus := make(map[string]bool)
+	for _, item := range inventory.Content {
+		if sku, ok := c.nonEmpty(item, "sku"); ok {
+			if skus[sku] {
+				c.add(value(item, "sku"), fmt.Sprintf("sku %s is listed more than once", sku))
+			}
+			skus[sku] = true
+		}
+		c.intAtLeast(item, "onhand", 0)
+	}
+	return c.result()
+}
+
+// decodeStrict decodes data into v, rejecting unknown fields and values
+// of the wrong type, and returns the top-level mapping of the document
+// for further checks. It returns a nil mapping if data cannot be
+// checked further.
+func decodeStrict(filename string, data []byte, v interface{}) (*yaml.Node, Errors) {
+	var errs Errors
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(v); err != nil && err != io.EOF {
+		errs = decodeErrors(filename, err)
+		if _, ok := err.(*yaml.TypeError); !ok {
+			return nil, errs
+		}
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, decodeErrors(filename, err)
+	}
+	n := document(&root)
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil, append(errs, &Error{File: filename, Message: "expected a mapping at the top level"})
+	}
+	return n, errs
+}
+
+var lineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// decodeErrors splits a YAML decoding error into an Error per line.
+func decodeErrors(filename string, err error) Errors {
+	msgs := []string{err.Error()}
+	if te, ok := err.(*yaml.TypeError); ok {
+		msgs = te.Errors
+	}
+	errs := make(Errors, 0, len(msgs))
+	for _, msg := range msgs {
+		e := Error{File: filename, Message: strings.TrimPrefix(msg, "yaml: ")}
+		if m := lineRegexp.FindStringSubmatch(msg); m != nil {
+			e.Line, _ = strconv.Atoi(m[1])
+			e.Message = m[2]
+		}
+		errs = append(errs, &e)
+	}
+	return errs
+}
+
+func document(n *yaml.Node) *yaml.Node {
+	if n.Kind == yaml.DocumentNode {
+		if len(n.Content) == 0 {
+			return nil
+		}
+		return n.Content[0]
+	}
+	return n
+}
+
+// value returns the value of key in the mapping n, or nil if n is not
+// a mapping or has no such key.
+func value(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// checker collects the errors found in a file.
+type checker struct {
+	file string
+	errs Errors
+}
+
+func (c *checker) add(n *yaml.Node, msg string) {
+	c.errs = append(c.errs, &Error{File: c.file, Line: n.Line, Message: msg})
+}
+
+func (c *checker) result() error {
+	if len(c.errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(c.errs, func(i, j int) bool { return c.errs[i].Line < c.errs[j].Line })
+	return c.errs
+}
+
+// required returns the value of key in the mapping n, reporting an
+// error if it is missing or null.
+func (c *checker) required(n *yaml.Node, key string) *yaml.Node {
+	v := value(n, key)
+	if v == nil || v.Tag == "!!null" {
+		c.add(n, fmt.Sprintf("missing %s", key))
+		return nil
+	}
+	return v
+}
+
+// nonEmpty returns the value of key in the mapping n, reporting an
+// error if it is missing or empty.
+func (c *checker) nonEmpty(n *yaml.Node, key string) (string, bool) {
+	v := c.required(n, key)
+	if v == nil {
+		return "", false
+	}
+	if v.Value == "" {
+		c.add(v, fmt.Sprintf("empty %s", key))
+		return "", false
+	}
+	return v.Value, true
+}
+
+// intAtLeast returns the integer value of key in the mapping n,
+// reporting an error if it is missing or less than min.
+func (c *checker) intAtLeast(n *yaml.Node, key string, min int) (int, bool) {
+	v := c.required(n, key)
+	if v == nil {
+		return 0, false
+	}
+	i, err := strconv.Atoi(v.Value)
+	if err != nil {
+		// reported by decodeStrict
+		return 0, false
+	}
+	if i < min {
+		c.add(v, fmt.Sprintf("%s must be at least %d, not %d", key, min, i))
+		return 0, false
+	}
+	return i, true
+}
@@ -0,0 +1,230 @@
+package cmdvalidate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		kind string
+		errs []string // file:line: message, in order
+	}{
+		{
+			name: "product",
+			data: `product:
+  sku: A
+  path: a
+  name: Apple
+  images:
+    - path: a.jpg
+  prices:
+    default:
+      - break: 1
+        unit_price: 1000
+      - break: 10
+        unit_price: 900
+`,
+			kind: KindProduct,
+		},
+		{
+			name: "product with problems",
+			data: `product:
+  sku: A
+  name: ""
+  colour: red
+  images:
+    - path: ""
+  prices:
+    default:
+      - break: 0
+        unit_price: -1
+      - break: 0
+        unit_price: 1
+`,
+			kind: KindProduct,
+			errs: []string{
+				"f.yaml:2: missing path",
+				"f.yaml:3: empty name",
+				"f.yaml:4: field colour not found in type eclient.ProductApplyYAML",
+				"f.yaml:6: empty path",
+				"f.yaml:9: break must be at least 1, not 0",
+				"f.yaml:10: unit_price must be at least 0, not -1",
+				"f.yaml:11: break must be at least 1, not 0",
+			},
+		},
+		{
+			name: "product with wrong types",
+			data: `product:
+  sku: A
+  path: a
+  name: A
+  prices:
+    default:
+      - break: one
+        unit_price: 100
+`,
+			kind: KindProduct,
+			errs: []string{"f.yaml:7: cannot unmarshal !!str `one` into int"},
+		},
+		{
+			name: "duplicate price break",
+			data: `product:
+  sku: A
+  path: a
+  name: A
+  prices:
+    default:
+      - break: 1
+        unit_price: 100
+      - break: 1
+        unit_price: 90
+`,
+			kind: KindProduct,
+			errs: []string{"f.yaml:9: price list default has more than one price for break 1"},
+		},
+		{
+			name: "catalog",
+			data: `endpoints:
+  - api.example.com
+catalog:
+  segment: root
+  name: Root
+  categories:
+    - segment: fruit
+      name: Fruit
+    - segment: veg
+      name: Veg
+`,
+			kind: KindCatalog,
+		},
+		{
+			name: "catalog with problems",
+			data: `endpoints:
+  - https://api.example.com/
+catalog:
+  segment: root
+  name: Root
+  categories:
+    - segment: Fruit
+      name: Fruit
+    - segment: veg
+      name: Veg
+    - segment: veg
+`,
+			kind: KindCatalog,
+			errs: []string{
+				`f.yaml:2: endpoint "https://api.example.com/" is not a host name`,
+				`f.yaml:7: segment "Fruit" must be lower case letters, digits, - and _`,
+				`f.yaml:11: duplicate segment "veg"`,
+				`f.yaml:11: missing name`,
+			},
+		},
+		{
+			name: "product category relations",
+			data: `product_category_relations:
+  fruit/apples:
+    products:
+      - A
+      - B
+`,
+			kind: KindProductCategoryRelations,
+		},
+		{
+			name: "product category relations with problems",
+			data: `product_category_relations:
+  fruit/apples:
+    products:
+      - A
+      - ""
+      - A
+`,
+			kind: KindProductCategoryRelations,
+			errs: []string{
+				"f.yaml:5: empty sku",
+				"f.yaml:6: sku A is listed more than once for fruit/apples",
+			},
+		},
+		{
+			name: "inventory",
+			data: `inventory:
+  - sku: A
+    onhand: 10
+    overselling: true
+  - sku: B
+    onhand: 0
+`,
+			kind: KindInventory,
+		},
+		{
+			name: "inventory with problems",
+			data: `inventory:
+  - sku: A
+    onhand: -1
+    overeselling: true
+  - sku: A
+    onhand: 1
+  - onhand: 2
+`,
+			kind: KindInventory,
+			errs: []string{
+				"f.yaml:3: onhand must be at least 0, not -1",
+				"f.yaml:4: field overeselling not found in type eclient.InventoryBatchYAML",
+				"f.yaml:5: sku A is listed more than once",
+				"f.yaml:7: missing sku",
+			},
+		},
+		{
+			name: "unknown kind",
+			data: "orders:\n  - id: 1\n",
+			errs: []string{"f.yaml:1: not a product, catalog, product category relations or inventory file"},
+		},
+		{
+			name: "not a mapping",
+			data: "- a\n- b\n",
+			errs: []string{"f.yaml: not a product, catalog, product category relations or inventory file"},
+		},
+		{
+			name: "syntax error",
+			data: "product:\n  sku: [\n",
+			errs: []string{"f.yaml:2: did not find expected node content"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, err := ValidateYAML("f.yaml", []byte(tt.data))
+			if kind != tt.kind {
+				t.Errorf("kind = %q, want %q", kind, tt.kind)
+			}
+			var got []string
+			if err != nil {
+				got = strings.Split(err.Error(), "\n")
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.errs, "\n") {
+				t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.errs, "\n"))
+			}
+		})
+	}
+}
+
+func TestValidateKindMismatch(t *testing.T) {
+	inventory := []byte("inventory:\n  - sku: A\n    onhand: 1\n")
+	err := Product("f.yaml", inventory)
+	want := "f.yaml:1: field inventory not found in type eclient.ProductContainerYAML\nf.yaml:1: missing product"
+	if err == nil || err.Error() != want {
+		t.Errorf("Product(inventory file) = %v, want:\n%s", err, want)
+	}
+}
+
+func TestErrorsCarryLines(t *testing.T) {
+	err := InventoryBatch("f.yaml", []byte("inventory:\n  - sku: A\n    onhand: -1\n"))
+	errs, ok := err.(Errors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("InventoryBatch error = %#v, want one Error", err)
+	}
+	if e := errs[0]; e.File != "f.yaml" || e.Line != 3 {
+		t.Errorf("error is at %s:%d, want f.yaml:3", e.File, e.Line)
+	}
+}
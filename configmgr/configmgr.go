@@ -2,21 +2,23 @@ package configmgr
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
+	"sort"
 	"strings"
 
-	"github.com/spf13/viper"
+	yaml "gopkg.in/yaml.v2"
 )
 
 // TokenAndRefreshToken contains a pair of JTW and refresh token for Firebase.
 type TokenAndRefreshToken struct {
-	IDToken      string `json:"idToken"`
-	RefreshToken string `json:"refreshToken"`
+	IDToken      string `json:"idToken" yaml:"id-token"`
+	RefreshToken string `json:"refreshToken" yaml:"refresh-token"`
 }
 
 // Customer details
@@ -29,15 +31,40 @@ type Customer struct {
 	Lastname  string `mapstructure:"lastname" yaml:"lastname"`
 }
 
-// EcomConfigEntry represents a single configuration set.
+// EcomConfigEntry represents a single configuration set. The developer
+// key is kept in the credential store rather than the config file; see
+// ReadConfig and WriteConfig.
 type EcomConfigEntry struct {
 	Endpoint string   `mapstructure:"endpoint" yaml:"endpoint"`
-	DevKey   string   `mapstructure:"developer-key" yaml:"developer-key"`
+	DevKey   string   `mapstructure:"developer-key" yaml:"developer-key,omitempty"`
 	Customer Customer `mapstructure:"user" yaml:"user"`
+
+	// Preferences change how times and prices are shown and give
+	// defaults for some commands.
+	Preferences Preferences `mapstructure:"preferences" yaml:"preferences,omitempty"`
+
+	// Environment tags the profile, for example "production".
+	// Destructive commands ask for confirmation before changing a
+	// protected profile; see Protected.
+	Environment string `mapstructure:"environment" yaml:"environment,omitempty"`
+
+	// Credential names the token and refresh token in the credential
+	// store of a profile created with ecom login. Profiles created with
+	// a developer key leave it empty; see TokenName.
+	Credential string `mapstructure:"credential" yaml:"credential,omitempty"`
+
+	// SecureTokenURL and IdentityToolkitURL override the base URLs of
+	// the Google identity services, for example to use the Firebase
+	// Auth emulator. Empty means the Google production services.
+	SecureTokenURL     string `mapstructure:"securetoken-url" yaml:"securetoken-url,omitempty"`
+	IdentityToolkitURL string `mapstructure:"identitytoolkit-url" yaml:"identitytoolkit-url,omitempty"`
 }
 
 // EcomConfigurations contains the map of config entries.
 type EcomConfigurations struct {
+	// Version is the version of the config file; see ConfigVersion.
+	Version int `mapstructure:"version" yaml:"version"`
+
 	Configurations map[string]EcomConfigEntry `mapstructure:"configurations" yaml:"configurations"`
 }
 
@@ -77,43 +104,8 @@ func ensureConfigDirExists() error {
 		return fmt.Errorf("failed homeDir(): %w", err)
 	}
 	cfgDir := filepath.Join(hd, configDir)
-
-	exists, err := exists(cfgDir)
-	if err != nil {
-		return fmt.Errorf("failed exists(%s): %w", configDir, err)
-	}
-	if !exists {
-		os.Mkdir(cfgDir, 0755)
-		err = WriteCurrentProject("")
-		if err != nil {
-			return fmt.Errorf("failed write current project %q: %w", "", err)
-		}
-	}
-	return nil
-}
-
-func ensureConfigFileExists() error {
-	hd, err := homeDir()
-	if err != nil {
-		return fmt.Errorf("failed homeDir(): %w", err)
-	}
-
-	cf := filepath.Join(hd, configFile)
-	exists, err := exists(cf)
-	if err != nil {
-		return fmt.Errorf("failed exists(%s): %w", configDir, err)
-	}
-
-	if !exists {
-		f, err := os.Create(cf)
-		if err != nil {
-			return fmt.Errorf("create(%q) failed: %w", cf, err)
-		}
-		defer f.Close()
-		_, err = f.WriteString("{}")
-		if err != nil {
-			return fmt.Errorf("write string to file %q failed: %w", cf, err)
-		}
+	if err := os.Mkdir(cfgDir, 0700); err != nil && !os.IsExist(err) {
+		return fmt.Errorf("mkdir %q failed: %w", cfgDir, err)
 	}
 	return nil
 }
@@ -127,93 +119,216 @@ func URLToHostName(u string) (string, error) {
 	return strings.ReplaceAll(url.Hostname(), ".", "_"), nil
 }
 
-// TokenFilename returns the full filepath of the file corresponding
-// to the given EcomConfigEntry.
-func TokenFilename(e *EcomConfigEntry) (string, error) {
-	hd, err := homeDir()
-	if err != nil {
-		return "", fmt.Errorf("homeDir() failed: %w", err)
-	}
+// Preferences are the per-profile display settings and defaults. Empty
+// fields take the defaults of the display package.
+type Preferences struct {
+	// Timezone is an IANA time zone name such as Europe/London.
+	Timezone string `mapstructure:"timezone" yaml:"timezone,omitempty" json:"timezone,omitempty"`
+
+	// TimeFormat is a Go time layout such as 2006-01-02 15:04.
+	TimeFormat string `mapstructure:"time-format" yaml:"time-format,omitempty" json:"time_format,omitempty"`
+
+	// Locale, such as en-GB or de-DE, sets the decimal and thousands
+	// separators of prices and where the currency symbol goes.
+	Locale string `mapstructure:"locale" yaml:"locale,omitempty" json:"locale,omitempty"`
+
+	// Currency is the ISO 4217 code of prices whose currency is not
+	// known, such as promo rule amounts.
+	Currency string `mapstructure:"currency" yaml:"currency,omitempty" json:"currency,omitempty"`
 
+	// PriceList is the default price list code.
+	PriceList string `mapstructure:"price-list" yaml:"price-list,omitempty" json:"price_list,omitempty"`
+
+	// Cart is the ID of the default cart, used by the carts commands
+	// when ECOM_CLI_CART_ID is not set.
+	Cart string `mapstructure:"cart" yaml:"cart,omitempty" json:"cart,omitempty"`
+}
+
+// ProductionEnvironment is the Environment of protected profiles.
+const ProductionEnvironment = "production"
+
+// Protected reports whether destructive commands must be confirmed
+// before they change the profile's API Service.
+func (e *EcomConfigEntry) Protected() bool {
+	return strings.EqualFold(e.Environment, ProductionEnvironment)
+}
+
+// TokenName returns the name of the file within the $HOME/.ecom
+// directory that holds the token and refresh token for the given
+// EcomConfigEntry. This is the entry's Credential if set, otherwise it
+// is derived from the endpoint and developer key.
+func TokenName(e *EcomConfigEntry) (string, error) {
+	if e.Credential != "" {
+		return e.Credential, nil
+	}
 	hostname, err := URLToHostName(e.Endpoint)
 	if err != nil {
 		return "", fmt.Errorf("url to hostname failed for %q: %w", e.Endpoint, err)
 	}
-	filename := fmt.Sprintf("%s-%s", hostname, e.DevKey[:6])
-	tokenFile := filepath.Join(hd, configDir, filename)
-	exists, err := exists(tokenFile)
-	if err != nil {
-		return "", fmt.Errorf("exists(%s) failed: %w", tokenFile, err)
+	if len(e.DevKey) < 6 {
+		return "", fmt.Errorf("developer key for %q is too short", e.Endpoint)
 	}
-	if !exists {
-		return "", fmt.Errorf("token file %q not found: %w", tokenFile, err)
-	}
-	return tokenFile, nil
+	return fmt.Sprintf("%s-%s", hostname, e.DevKey[:6]), nil
 }
 
 // ReadCurrentConfigName returns the contents of the CURRENT_PROJECT
-// file. If the CURRENT_PROJECT file does not exists (for example, the
-// first time the program is run), an empty file will be created.
+// file, or an empty string if the file does not exist (for example, the
+// first time the program is run).
 func ReadCurrentConfigName() (string, error) {
-	hd, err := homeDir()
+	unlock, err := lockConfig()
 	if err != nil {
-		return "", fmt.Errorf("homeDir() failed: %w", err)
+		return "", err
 	}
-	err = ensureConfigDirExists()
+	defer unlock()
+	return readCurrentProject()
+}
+
+func currentProjectFile() (string, error) {
+	hd, err := homeDir()
 	if err != nil {
-		return "", fmt.Errorf("ensure config dir exists failed: %w", err)
+		return "", fmt.Errorf("homeDir() failed: %w", err)
 	}
-	cpf := filepath.Join(hd, configDir, "CURRENT_PROJECT")
-	exists, err := exists(cpf)
+	return filepath.Join(hd, configDir, "CURRENT_PROJECT"), nil
+}
+
+func readCurrentProject() (string, error) {
+	cpf, err := currentProjectFile()
 	if err != nil {
-		return "", fmt.Errorf("exists(%s) failed: %w", cpf, err)
+		return "", err
 	}
-	if !exists {
-		f, err := os.Create(cpf)
-		if err != nil {
-			return "", fmt.Errorf("create file %q failed: %w", cpf, err)
-		}
-		defer f.Close()
+	bs, err := ioutil.ReadFile(cpf)
+	if os.IsNotExist(err) {
 		return "", nil
 	}
-	bs, err := ioutil.ReadFile(cpf)
 	if err != nil {
 		return "", fmt.Errorf("read file %q failed: %w", cpf, err)
 	}
 	return string(bs), nil
 }
 
+func writeCurrentProject(name string) error {
+	cpf, err := currentProjectFile()
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(cpf, []byte(name), 0644); err != nil {
+		return fmt.Errorf("write CURRENT_PROJECT file failed: %w", err)
+	}
+	return nil
+}
+
 // ReadConfig opens and read the .ecomrc.yaml file putting each section
-// name in a map of EcomConfigEntrys.
+// name in a map of EcomConfigEntrys. Developer keys are read from the
+// credential store. A config file written by an earlier release is
+// upgraded first, see ConfigVersion; one written by a later release is
+// refused with a *ConfigVersionError.
 func ReadConfig() (*EcomConfigurations, error) {
-	err := ensureConfigFileExists()
+	unlock, err := lockConfig()
 	if err != nil {
-		return nil, fmt.Errorf("ensure config file exists failed: %w", err)
+		return nil, err
 	}
-	viper.SetConfigName(".ecomrc")
-	viper.SetConfigType("yaml")
+	defer unlock()
+	return readConfig()
+}
+
+func readConfig() (*EcomConfigurations, error) {
 	hd, err := homeDir()
 	if err != nil {
 		return nil, err
 	}
-	viper.AddConfigPath(hd)
-	viper.ReadInConfig()
+	store, err := Credentials()
 	if err != nil {
-		return nil, fmt.Errorf("read in config file failed: %w", err)
+		return nil, err
 	}
-	configurations := EcomConfigurations{}
-	err = viper.Unmarshal(&configurations)
+	data, err := readConfigFile(filepath.Join(hd, configFile), store)
 	if err != nil {
+		return nil, err
+	}
+	configurations := EcomConfigurations{}
+	if err := yaml.Unmarshal(data, &configurations); err != nil {
 		return nil, fmt.Errorf("unmarshal configurations failed: %w", err)
 	}
+
+	// developer keys added to the config file by hand
+	inConfig, err := loadDevKeys(store, &configurations)
+	if err != nil {
+		return nil, err
+	}
+	if len(inConfig) > 0 {
+		if err := writeConfig(store, &configurations); err != nil {
+			return nil, fmt.Errorf("move developer keys to the credential store failed: %w", err)
+		}
+	}
 	return &configurations, nil
 }
 
-// WriteConfig writes the EcomConfigurations to the YAML config.
+// WriteConfig writes the EcomConfigurations to the YAML config. The
+// developer keys are written to the credential store and left out of
+// the config file. Use UpdateConfig to change the configuration read
+// from the file.
 func WriteConfig(cfgs *EcomConfigurations) error {
-	viper.Set("configurations", cfgs.Configurations)
-	err := viper.WriteConfig()
+	unlock, err := lockConfig()
 	if err != nil {
+		return err
+	}
+	defer unlock()
+	store, err := Credentials()
+	if err != nil {
+		return err
+	}
+	return writeConfig(store, cfgs)
+}
+
+// UpdateConfig reads the configuration, calls fn to change it and
+// writes it back, holding the lock throughout so concurrent ecom
+// processes cannot lose each other's changes. If fn returns an error
+// the configuration is left unchanged.
+func UpdateConfig(fn func(cfgs *EcomConfigurations) error) error {
+	unlock, err := lockConfig()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	cfgs, err := readConfig()
+	if err != nil {
+		return err
+	}
+	if cfgs.Configurations == nil {
+		cfgs.Configurations = make(map[string]EcomConfigEntry)
+	}
+	if err := fn(cfgs); err != nil {
+		return err
+	}
+	store, err := Credentials()
+	if err != nil {
+		return err
+	}
+	return writeConfig(store, cfgs)
+}
+
+func writeConfig(store CredentialStore, cfgs *EcomConfigurations) error {
+	hd, err := homeDir()
+	if err != nil {
+		return err
+	}
+	entries := make(map[string]EcomConfigEntry, len(cfgs.Configurations))
+	for name, e := range cfgs.Configurations {
+		if e.DevKey != "" {
+			if err := store.Set(DevKeyName(name), []byte(e.DevKey)); err != nil {
+				return fmt.Errorf("write developer key of profile %q failed: %w", name, err)
+			}
+			e.DevKey = ""
+		}
+		entries[name] = e
+	}
+	data, err := yaml.Marshal(&EcomConfigurations{
+		Version:        ConfigVersion,
+		Configurations: entries,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal configurations failed: %w", err)
+	}
+	if err := writeFileAtomic(filepath.Join(hd, configFile), data, 0600); err != nil {
 		return fmt.Errorf("write config file failed: %w", err)
 	}
 	return nil
@@ -223,91 +338,199 @@ func WriteConfig(cfgs *EcomConfigurations) error {
 // directory in a file called CURRENT_API_KEY. The current API Key context is read
 // between invocation of the command-line tool.
 func WriteCurrentProject(name string) error {
-	err := ensureConfigDirExists()
+	unlock, err := lockConfig()
 	if err != nil {
-		return fmt.Errorf("couldn't ensure config dir exists: %w", err)
+		return err
 	}
-	hd, err := homeDir()
+	defer unlock()
+	return writeCurrentProject(name)
+}
+
+// RenameProfile renames the profile oldName to newName, moving its
+// developer key in the credential store and updating CURRENT_PROJECT if
+// it is the selected profile. The token is stored under a name derived
+// from the endpoint and developer key, see TokenName, so it is
+// unaffected.
+func RenameProfile(oldName, newName string) error {
+	unlock, err := lockConfig()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-	cpf := filepath.Join(hd, configDir, "CURRENT_PROJECT")
-	bs := []byte(name)
-	err = ioutil.WriteFile(cpf, bs, 0644)
+	defer unlock()
+	cfgs, err := readConfig()
 	if err != nil {
-		return fmt.Errorf("write CURRENT_PROJECT file failed: %w", err)
+		return err
 	}
-	return nil
-}
+	e, ok := cfgs.Configurations[oldName]
+	if !ok {
+		return fmt.Errorf("profile %q not found", oldName)
+	}
+	if _, ok := cfgs.Configurations[newName]; ok {
+		return fmt.Errorf("profile %q already exists", newName)
+	}
+	delete(cfgs.Configurations, oldName)
+	cfgs.Configurations[newName] = e
 
-// DeleteProject removes a project from the .ecom directory returning ok true if successful.
-func DeleteProject(filename string) (bool, error) {
-	hd, err := homeDir()
+	store, err := Credentials()
 	if err != nil {
-		return false, fmt.Errorf("failed to get home directory: %w", err)
+		return err
+	}
+	if err := writeConfig(store, cfgs); err != nil {
+		return err
+	}
+	err = store.Delete(DevKeyName(oldName))
+	if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return fmt.Errorf("remove developer key of profile %q failed: %w", oldName, err)
 	}
-	filepath := filepath.Join(hd, configDir, filename)
 
-	exists, err := exists(filepath)
+	current, err := readCurrentProject()
 	if err != nil {
-		return false, fmt.Errorf("exists(path=%q) failed: %w", filepath, err)
+		return err
 	}
-	if !exists {
-		return false, fmt.Errorf("filename %q not found: %w", filepath, err)
+	if current == oldName {
+		return writeCurrentProject(newName)
 	}
-	err = os.Remove(filepath)
+	return nil
+}
+
+// DeleteProject removes the token and refresh token with the given
+// name from the credential store, returning ok true if successful.
+func DeleteProject(filename string) (bool, error) {
+	unlock, err := lockConfig()
+	if err != nil {
+		return false, err
+	}
+	defer unlock()
+	store, err := Credentials()
 	if err != nil {
-		return false, fmt.Errorf("failed to remove file %q: %w", filepath, err)
+		return false, err
+	}
+	if err := store.Delete(filename); err != nil {
+		return false, err
 	}
 	return true, nil
 }
 
-// ReadTokenAndRefreshToken reads the token and refresh token from the filesystem
-// or returns nil if the file has not yet been created.
-func ReadTokenAndRefreshToken(fp string) (*TokenAndRefreshToken, error) {
-	exists, err := exists(fp)
+// ReadTokenAndRefreshToken reads the token and refresh token with the
+// given name, see TokenName, from the credential store.
+func ReadTokenAndRefreshToken(filename string) (*TokenAndRefreshToken, error) {
+	unlock, err := lockConfig()
 	if err != nil {
-		return nil, fmt.Errorf("exists(path=%q) failed: %w", fp, err)
+		return nil, err
 	}
-
-	if !exists {
-		return nil, fmt.Errorf("token file %q not found: %w", fp, err)
+	defer unlock()
+	store, err := Credentials()
+	if err != nil {
+		return nil, err
 	}
-
-	f, err := os.Open(fp)
+	data, err := store.Get(filename)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file %q: %w", fp, err)
+		return nil, err
 	}
-	defer f.Close()
-
 	var tar TokenAndRefreshToken
-	json.NewDecoder(f).Decode(&tar)
+	if err := json.Unmarshal(data, &tar); err != nil {
+		return nil, fmt.Errorf("json decode token %q failed: %w", filename, err)
+	}
 	return &tar, nil
 }
 
-// WriteTokenAndRefreshToken writes a copy of the token and refresh token to file.
+// WriteTokenAndRefreshToken writes a copy of the token and refresh token
+// to the credential store under the given name.
 func WriteTokenAndRefreshToken(filename string, tar *TokenAndRefreshToken) error {
-	err := ensureConfigDirExists()
+	data, err := json.Marshal(tar)
 	if err != nil {
-		return fmt.Errorf("couldn't ensure config dir exists: %w", err)
+		return fmt.Errorf("json encode token failed: %w", err)
 	}
-	hd, err := homeDir()
+	unlock, err := lockConfig()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return err
 	}
-	filepath := filepath.Join(hd, configDir, filename)
-
-	f, err := os.Create(filepath)
+	defer unlock()
+	store, err := Credentials()
 	if err != nil {
-		return fmt.Errorf("create file %q failed: %w", filepath, err)
+		return err
 	}
-	defer f.Close()
+	return store.Set(filename, data)
+}
 
-	err = json.NewEncoder(f).Encode(tar)
-	if err != nil {
-		return fmt.Errorf("json encode token failed: %w", err)
+// ProfileEnv names the environment variable that selects the profile in
+// place of the CURRENT_PROJECT file.
+const ProfileEnv = "ECOM_PROFILE"
+
+// EndpointEnv and DevKeyEnv name the environment variables holding the
+// endpoint and developer key for an ephemeral profile, see
+// EphemeralProfile. They are also the defaults for profiles create.
+const (
+	EndpointEnv = "ECOM_ENDPOINT"
+	DevKeyEnv   = "ECOM_DEVELOPER_KEY"
+)
+
+// profileOverride and endpointOverride are set by SetOverride.
+var profileOverride, endpointOverride string
+
+// SetOverride selects the profile used by GetCurrentConfig for the rest
+// of this process, either by name or by its endpoint, in place of the
+// ECOM_PROFILE environment variable and the CURRENT_PROJECT file. The
+// CURRENT_PROJECT file itself is left untouched. Empty values are
+// ignored.
+func SetOverride(profile, endpoint string) {
+	profileOverride = profile
+	endpointOverride = endpoint
+}
+
+// EphemeralProfile returns the profile given by the ECOM_ENDPOINT and
+// ECOM_DEVELOPER_KEY environment variables. It reports false unless
+// both are set and no profile has been chosen with SetOverride or
+// ECOM_PROFILE. An ephemeral profile is not saved; commands using it
+// sign in with the developer key and keep the tokens in memory, so the
+// configuration files are neither read nor written.
+func EphemeralProfile() (*EcomConfigEntry, bool) {
+	if profileOverride != "" || endpointOverride != "" || os.Getenv(ProfileEnv) != "" {
+		return nil, false
+	}
+	endpoint, devKey := os.Getenv(EndpointEnv), os.Getenv(DevKeyEnv)
+	if endpoint == "" || devKey == "" {
+		return nil, false
+	}
+	return &EcomConfigEntry{Endpoint: endpoint, DevKey: devKey}, true
+}
+
+// currentConfigName returns the name of the selected profile. In order
+// of precedence it is the profile given to SetOverride, the profile with
+// the endpoint given to SetOverride, the ECOM_PROFILE environment
+// variable or the contents of the CURRENT_PROJECT file.
+func currentConfigName(cfgs *EcomConfigurations) (string, error) {
+	if profileOverride != "" {
+		if _, ok := cfgs.Configurations[profileOverride]; !ok {
+			return "", fmt.Errorf("profile %q not found", profileOverride)
+		}
+		return profileOverride, nil
+	}
+	if endpointOverride != "" {
+		want := strings.TrimSuffix(endpointOverride, "/")
+		var match []string
+		for name, e := range cfgs.Configurations {
+			if strings.TrimSuffix(e.Endpoint, "/") == want {
+				match = append(match, name)
+			}
+		}
+		switch len(match) {
+		case 0:
+			return "", fmt.Errorf("no profile with endpoint %q", endpointOverride)
+		case 1:
+			return match[0], nil
+		}
+		sort.Strings(match)
+		return "", fmt.Errorf("endpoint %q matches profiles %s; use --profile to choose one",
+			endpointOverride, strings.Join(match, ", "))
 	}
-	return nil
+	if name := os.Getenv(ProfileEnv); name != "" {
+		if _, ok := cfgs.Configurations[name]; !ok {
+			return "", fmt.Errorf("profile %q set by %s not found", name, ProfileEnv)
+		}
+		return name, nil
+	}
+	return ReadCurrentConfigName()
 }
 
 // GetCurrentConfig returns a EcomConfigurations struct containing a map
@@ -318,9 +541,9 @@ func GetCurrentConfig() (cfgs *EcomConfigurations, curCfg string, err error) {
 	if err != nil {
 		return nil, "", fmt.Errorf("ReadConfig failed: %w", err)
 	}
-	curCfg, err = ReadCurrentConfigName()
+	curCfg, err = currentConfigName(cfgs)
 	if err != nil {
-		return nil, "", fmt.Errorf("ReadCurrentConfigName failed: %w", err)
+		return nil, "", fmt.Errorf("select profile failed: %w", err)
 	}
 	return cfgs, curCfg, nil
 }
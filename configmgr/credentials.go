@@ -0,0 +1,190 @@
+package configmgr
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrCredentialNotFound is returned by a CredentialStore for a name it
+// holds no credential for.
+var ErrCredentialNotFound = errors.New("credential not found")
+
+// CredentialStore holds the secrets of the profiles: the token and
+// refresh token pair, named by TokenName, and the developer key, named
+// by DevKeyName. Names must be valid file names.
+type CredentialStore interface {
+	Get(name string) ([]byte, error)
+	Set(name string, data []byte) error
+	Delete(name string) error
+}
+
+// PassphraseEnv names the environment variable holding the passphrase
+// for the encrypted credential store.
+const PassphraseEnv = "ECOM_PASSPHRASE"
+
+// Credentials returns the credential store in the $HOME/.ecom
+// directory. If ECOM_PASSPHRASE is set, credentials are encrypted with
+// it as they are written; otherwise they are written in plain text,
+// readable only by the owner.
+func Credentials() (CredentialStore, error) {
+	if err := ensureConfigDirExists(); err != nil {
+		return nil, fmt.Errorf("ensure config dir exists failed: %w", err)
+	}
+	hd, err := homeDir()
+	if err != nil {
+		return nil, fmt.Errorf("homeDir() failed: %w", err)
+	}
+	passphrase := os.Getenv(PassphraseEnv)
+
+	// keep the store, and so the keys the encrypted store derives, for
+	// the life of the process
+	credentialsMu.Lock()
+	defer credentialsMu.Unlock()
+	if credentials == nil || credentialsPassphrase != passphrase {
+		fs := NewFileStore(filepath.Join(hd, configDir))
+		if passphrase != "" {
+			credentials = NewEncryptedStore(fs, passphrase)
+		} else {
+			credentials = plainStore{fs}
+		}
+		credentialsPassphrase = passphrase
+	}
+	return credentials, nil
+}
+
+var (
+	credentialsMu         sync.Mutex
+	credentials           CredentialStore
+	credentialsPassphrase string
+)
+
+// fileStore is a CredentialStore keeping each credential in its own
+// file.
+type fileStore struct {
+	dir string
+}
+
+// NewFileStore returns a CredentialStore keeping each credential in a
+// file of the same name in dir, readable and writable only by the
+// owner. Files are replaced atomically.
+func NewFileStore(dir string) CredentialStore {
+	return &fileStore{dir: dir}
+}
+
+func (s *fileStore) path(name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\`) {
+		return "", fmt.Errorf("invalid credential name %q", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+func (s *fileStore) Get(name string) ([]byte, error) {
+	p, err := s.path(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%q: %w", p, ErrCredentialNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read file %q failed: %w", p, err)
+	}
+	// tighten the permissions of files written by older versions
+	if fi, err := os.Stat(p); err == nil && fi.Mode().Perm()&0077 != 0 {
+		os.Chmod(p, 0600)
+	}
+	return data, nil
+}
+
+func (s *fileStore) Set(name string, data []byte) error {
+	p, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(p, data, 0600)
+}
+
+func (s *fileStore) Delete(name string) error {
+	p, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(p)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%q: %w", p, ErrCredentialNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("remove file %q failed: %w", p, err)
+	}
+	return nil
+}
+
+// plainStore refuses to return credentials written by an encrypted
+// store, which would otherwise be mistaken for plain text.
+type plainStore struct {
+	CredentialStore
+}
+
+func (s plainStore) Get(name string) ([]byte, error) {
+	data, err := s.CredentialStore.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := parseSealed(data); ok {
+		return nil, fmt.Errorf("credential %q is encrypted; set %s to read it", name, PassphraseEnv)
+	}
+	return data, nil
+}
+
+// DevKeyName returns the name of the credential holding the developer
+// key of the named profile.
+func DevKeyName(profile string) string {
+	return profile + ".devkey"
+}
+
+// loadDevKeys sets the developer key of each profile from the
+// credential store. Profiles whose developer key is still in the config
+// file are returned; loadDevKeys leaves them unchanged.
+func loadDevKeys(store CredentialStore, cfgs *EcomConfigurations) (inConfig []string, err error) {
+	for name, e := range cfgs.Configurations {
+		if e.DevKey != "" {
+			inConfig = append(inConfig, name)
+			continue
+		}
+		key, err := store.Get(DevKeyName(name))
+		if errors.Is(err, ErrCredentialNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read developer key of profile %q failed: %w", name, err)
+		}
+		e.DevKey = string(key)
+		cfgs.Configurations[name] = e
+	}
+	return inConfig, nil
+}
+
+// DeleteDevKey removes the developer key of the named profile from the
+// credential store. It is not an error if there is none.
+func DeleteDevKey(profile string) error {
+	unlock, err := lockConfig()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	store, err := Credentials()
+	if err != nil {
+		return err
+	}
+	err = store.Delete(DevKeyName(profile))
+	if err != nil && !errors.Is(err, ErrCredentialNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,185 @@
+package configmgr
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// scrypt parameters for new credentials, as recommended for interactive
+// use in 2017. Sealed credentials record their own parameters.
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+)
+
+// sealed is the stored form of an encrypted credential. The key is
+// derived from the passphrase and Salt with scrypt and the credential
+// is sealed with AES-256-GCM, using its name as additional data so a
+// sealed credential cannot be passed off as another.
+type sealed struct {
+	Encrypted  string `json:"encrypted"`
+	N          int    `json:"n"`
+	R          int    `json:"r"`
+	P          int    `json:"p"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// sealedVersion identifies the format of sealed credentials.
+const sealedVersion = "scrypt-aes256gcm-v1"
+
+// parseSealed reports whether data is an encrypted credential.
+func parseSealed(data []byte) (*sealed, bool) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return nil, false
+	}
+	var s sealed
+	if err := json.Unmarshal(data, &s); err != nil || s.Encrypted == "" {
+		return nil, false
+	}
+	return &s, true
+}
+
+// encryptedStore seals credentials before passing them to the
+// underlying store.
+type encryptedStore struct {
+	store      CredentialStore
+	passphrase []byte
+
+	// mu guards keys, the derived keys by salt and scrypt parameters.
+	// Deriving a key is deliberately slow, so new credentials reuse the
+	// salt of the first key derived.
+	mu   sync.Mutex
+	keys map[string][]byte
+	salt []byte
+}
+
+// NewEncryptedStore returns a CredentialStore that encrypts credentials
+// with a key derived from passphrase before saving them in store.
+// Credentials in store that are not encrypted, such as those written
+// before the passphrase was set, are returned as they are and encrypted
+// when next written.
+func NewEncryptedStore(store CredentialStore, passphrase string) CredentialStore {
+	return &encryptedStore{
+		store:      store,
+		passphrase: []byte(passphrase),
+		keys:       make(map[string][]byte),
+	}
+}
+
+func (s *encryptedStore) key(salt []byte, n, r, p int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := fmt.Sprintf("%x/%d/%d/%d", salt, n, r, p)
+	if k, ok := s.keys[id]; ok {
+		return k, nil
+	}
+	k, err := scrypt.Key(s.passphrase, salt, n, r, p, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive key failed: %w", err)
+	}
+	s.keys[id] = k
+	if s.salt == nil && n == scryptN && r == scryptR && p == scryptP {
+		s.salt = salt
+	}
+	return k, nil
+}
+
+func (s *encryptedStore) newSalt() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.salt != nil {
+		return s.salt, nil
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("generate salt failed: %w", err)
+	}
+	return salt, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (s *encryptedStore) Get(name string) ([]byte, error) {
+	data, err := s.store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	sl, ok := parseSealed(data)
+	if !ok {
+		return data, nil
+	}
+	if sl.Encrypted != sealedVersion {
+		return nil, fmt.Errorf("credential %q: unsupported encryption %q", name, sl.Encrypted)
+	}
+	if sl.N > 1<<20 || sl.R*sl.P > 64 {
+		return nil, fmt.Errorf("credential %q: scrypt parameters out of range", name)
+	}
+	key, err := s.key(sl.Salt, sl.N, sl.R, sl.P)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sl.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("credential %q: bad nonce", name)
+	}
+	plain, err := gcm.Open(nil, sl.Nonce, sl.Ciphertext, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credential %q failed; is %s correct?", name, PassphraseEnv)
+	}
+	return plain, nil
+}
+
+func (s *encryptedStore) Set(name string, data []byte) error {
+	salt, err := s.newSalt()
+	if err != nil {
+		return err
+	}
+	key, err := s.key(salt, scryptN, scryptR, scryptP)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generate nonce failed: %w", err)
+	}
+	b, err := json.Marshal(sealed{
+		Encrypted:  sealedVersion,
+		N:          scryptN,
+		R:          scryptR,
+		P:          scryptP,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, data, []byte(name)),
+	})
+	if err != nil {
+		return fmt.Errorf("json encode credential failed: %w", err)
+	}
+	return s.store.Set(name, b)
+}
+
+func (s *encryptedStore) Delete(name string) error {
+	return s.store.Delete(name)
+}
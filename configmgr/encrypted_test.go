@@ -0,0 +1,149 @@
+package configmgr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// memStore is an in-memory CredentialStore.
+type memStore map[string][]byte
+
+func (m memStore) Get(name string) ([]byte, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, ErrCredentialNotFound
+	}
+	return data, nil
+}
+
+func (m memStore) Set(name string, data []byte) error {
+	m[name] = data
+	return nil
+}
+
+func (m memStore) Delete(name string) error {
+	delete(m, name)
+	return nil
+}
+
+func TestEncryptedStoreRoundTrip(t *testing.T) {
+	mem := memStore{}
+	store := NewEncryptedStore(mem, "correct horse")
+	secret := []byte("refresh-token-value")
+	if err := store.Set("a.token", secret); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	raw := mem["a.token"]
+	if bytes.Contains(raw, secret) {
+		t.Fatalf("stored credential contains the plaintext: %s", raw)
+	}
+	sl, ok := parseSealed(raw)
+	if !ok || sl.Encrypted != sealedVersion {
+		t.Fatalf("stored credential is not sealed: %s", raw)
+	}
+
+	got, err := store.Get("a.token")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("Get = %q, want %q", got, secret)
+	}
+
+	// a new store with the same passphrase, as in the next invocation
+	got, err = NewEncryptedStore(mem, "correct horse").Get("a.token")
+	if err != nil {
+		t.Fatalf("Get from new store: %v", err)
+	}
+	if !bytes.Equal(got, secret) {
+		t.Errorf("Get from new store = %q, want %q", got, secret)
+	}
+}
+
+func TestEncryptedStoreGet(t *testing.T) {
+	mem := memStore{}
+	if err := NewEncryptedStore(mem, "correct horse").Set("a.token", []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	sealedA := mem["a.token"]
+
+	reseal := func(f func(s *sealed)) []byte {
+		sl, _ := parseSealed(sealedA)
+		f(sl)
+		b, _ := json.Marshal(sl)
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		passphrase string
+		stored     []byte // as a.token, or nil for none
+		want       string
+		err        string
+	}{
+		{"plaintext from before the passphrase", "correct horse", []byte("plain"), "plain", ""},
+		{"JSON that is not sealed", "correct horse", []byte(`{"id_token":"x"}`), `{"id_token":"x"}`, ""},
+		{"wrong passphrase", "battery staple", sealedA, "", "is ECOM_PASSPHRASE correct?"},
+		{"unsupported encryption", "correct horse",
+			reseal(func(s *sealed) { s.Encrypted = "rot13" }), "", "unsupported encryption"},
+		{"scrypt parameters out of range", "correct horse",
+			reseal(func(s *sealed) { s.N = 1 << 30 }), "", "out of range"},
+		{"bad nonce", "correct horse",
+			reseal(func(s *sealed) { s.Nonce = s.Nonce[:4] }), "", "bad nonce"},
+		{"tampered ciphertext", "correct horse",
+			reseal(func(s *sealed) { s.Ciphertext[0] ^= 1 }), "", "decrypt credential"},
+		{"missing", "correct horse", nil, "", ErrCredentialNotFound.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := memStore{}
+			if tt.stored != nil {
+				m["a.token"] = tt.stored
+			}
+			got, err := NewEncryptedStore(m, tt.passphrase).Get("a.token")
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("Get error = %v, want one containing %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Get = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncryptedStoreBindsName(t *testing.T) {
+	mem := memStore{}
+	store := NewEncryptedStore(mem, "correct horse")
+	if err := store.Set("a.token", []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	// a credential copied under another name must not decrypt
+	mem["b.token"] = mem["a.token"]
+	if _, err := store.Get("b.token"); err == nil {
+		t.Error("Get of a credential sealed under another name succeeded")
+	}
+}
+
+func TestEncryptedStoreDelete(t *testing.T) {
+	mem := memStore{}
+	store := NewEncryptedStore(mem, "correct horse")
+	if err := store.Set("a.token", []byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete("a.token"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get("a.token"); !errors.Is(err, ErrCredentialNotFound) {
+		t.Errorf("Get after Delete = %v, want ErrCredentialNotFound", err)
+	}
+}
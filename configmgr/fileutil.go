@@ -0,0 +1,43 @@
+package configmgr
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes data to a temporary file in the directory of
+// path and renames it over path, so readers see either the old or the
+// new contents and never a partly written file. The file is created
+// with permissions perm.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir, base := filepath.Split(path)
+	tmp, err := ioutil.TempFile(dir, "."+base+".tmp")
+	if err != nil {
+		return fmt.Errorf("create temp file for %q failed: %w", path, err)
+	}
+	// remove the temp file if anything below fails; after a successful
+	// rename this is a no-op
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("chmod %q failed: %w", tmp.Name(), err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write %q failed: %w", tmp.Name(), err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync %q failed: %w", tmp.Name(), err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close %q failed: %w", tmp.Name(), err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("rename %q to %q failed: %w", tmp.Name(), path, err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package configmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// lockName is the file in the $HOME/.ecom directory that ecom processes
+// lock while they read or write the config, CURRENT_PROJECT and token
+// files.
+const lockName = ".lock"
+
+// lockMu serialises locking within the process; the file lock
+// serialises ecom processes.
+var lockMu sync.Mutex
+
+// lockConfig takes an exclusive advisory lock on the configuration,
+// waiting for other ecom processes to release it, and returns a function
+// that releases it. Locks are not reentrant: functions holding the lock
+// must only call the unlocked variants of the functions that take it.
+func lockConfig() (unlock func(), err error) {
+	if err := ensureConfigDirExists(); err != nil {
+		return nil, fmt.Errorf("ensure config dir exists failed: %w", err)
+	}
+	hd, err := homeDir()
+	if err != nil {
+		return nil, fmt.Errorf("homeDir() failed: %w", err)
+	}
+	lp := filepath.Join(hd, configDir, lockName)
+
+	lockMu.Lock()
+	f, err := os.OpenFile(lp, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		lockMu.Unlock()
+		return nil, fmt.Errorf("open lock file %q failed: %w", lp, err)
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		lockMu.Unlock()
+		return nil, fmt.Errorf("lock %q failed: %w", lp, err)
+	}
+	return func() {
+		unlockFile(f)
+		f.Close()
+		lockMu.Unlock()
+	}, nil
+}
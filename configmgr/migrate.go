@@ -0,0 +1,146 @@
+package configmgr
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ConfigVersion is the version of the config file written by this
+// release. Files without a version key are version 0.
+const ConfigVersion = 1
+
+// ConfigVersionError is returned when the config file was written by a
+// newer release, whose changes this release would not understand.
+type ConfigVersionError struct {
+	Path    string
+	Version int
+}
+
+func (e *ConfigVersionError) Error() string {
+	return fmt.Sprintf("config file %q is version %d but this ecom only understands up to version %d; upgrade ecom",
+		e.Path, e.Version, ConfigVersion)
+}
+
+// A migration upgrades the config file from version to version+1. It
+// works on the decoded YAML document rather than EcomConfigurations, so
+// it can read keys later releases no longer have.
+type migration struct {
+	version     int
+	description string
+	migrate     func(doc map[interface{}]interface{}, store CredentialStore) error
+}
+
+// migrations are applied in order to bring a config file up to
+// ConfigVersion. Add a migration, and bump ConfigVersion, whenever a
+// release changes the meaning of an existing key.
+var migrations = []migration{
+	{
+		version:     0,
+		description: "move developer keys to the credential store",
+		migrate:     migrateDevKeys,
+	},
+}
+
+// migrateDevKeys moves the developer keys written by releases before
+// the credential store into it.
+func migrateDevKeys(doc map[interface{}]interface{}, store CredentialStore) error {
+	profiles, _ := doc["configurations"].(map[interface{}]interface{})
+	for name, v := range profiles {
+		entry, ok := v.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		key, _ := entry["developer-key"].(string)
+		if key == "" {
+			continue
+		}
+		if err := store.Set(DevKeyName(fmt.Sprint(name)), []byte(key)); err != nil {
+			return fmt.Errorf("write developer key of profile %q failed: %w", name, err)
+		}
+		delete(entry, "developer-key")
+	}
+	return nil
+}
+
+// configVersion returns the version of the config file contents data.
+func configVersion(data []byte) (int, error) {
+	var v struct {
+		Version int `yaml:"version"`
+	}
+	if err := yaml.Unmarshal(data, &v); err != nil {
+		return 0, fmt.Errorf("unmarshal configurations failed: %w", err)
+	}
+	return v.Version, nil
+}
+
+// migrateConfig upgrades the config file at path, whose contents are
+// data, from version to ConfigVersion. The original is kept alongside
+// as .ecomrc.yaml.v<version>.bak, unless it has no profiles, in which
+// case the version is stamped quietly. It returns the upgraded contents.
+func migrateConfig(path string, data []byte, version int, store CredentialStore) ([]byte, error) {
+	doc := make(map[interface{}]interface{})
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unmarshal configurations failed: %w", err)
+	}
+
+	cfgs, _ := doc["configurations"].(map[interface{}]interface{})
+	quiet := len(cfgs) == 0
+
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if !quiet {
+		if err := writeFileAtomic(backup, data, 0600); err != nil {
+			return nil, fmt.Errorf("back up config file failed: %w", err)
+		}
+	}
+	for _, m := range migrations {
+		if m.version < version {
+			continue
+		}
+		if err := m.migrate(doc, store); err != nil {
+			return nil, fmt.Errorf("upgrade config file to version %d (%s) failed: %w",
+				m.version+1, m.description, err)
+		}
+	}
+	doc["version"] = ConfigVersion
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshal configurations failed: %w", err)
+	}
+	if err := writeFileAtomic(path, out, 0600); err != nil {
+		return nil, fmt.Errorf("write config file failed: %w", err)
+	}
+	if !quiet {
+		fmt.Fprintf(os.Stderr, "Upgraded %s from version %d to %d; the original is saved as %s.\n",
+			filepath.Base(path), version, ConfigVersion, filepath.Base(backup))
+	}
+	return out, nil
+}
+
+// readConfigFile returns the contents of the config file at path,
+// upgraded to ConfigVersion if it was written by an earlier release. A
+// missing file reads as empty.
+func readConfigFile(path string, store CredentialStore) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read config file failed: %w", err)
+	}
+	version, err := configVersion(data)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case version > ConfigVersion:
+		return nil, &ConfigVersionError{Path: path, Version: version}
+	case version < ConfigVersion && len(data) > 0:
+		return migrateConfig(path, data, version, store)
+	}
+	return data, nil
+}
@@ -0,0 +1,156 @@
+package configmgr
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// failStore is a CredentialStore that cannot be written.
+type failStore struct{ memStore }
+
+func (failStore) Set(name string, data []byte) error {
+	return errors.New("read-only")
+}
+
+func TestReadConfigFile(t *testing.T) {
+	const v0 = `configurations:
+  dev:
+    endpoint: https://dev.example.com
+    developer-key: dk-dev
+  live:
+    endpoint: https://live.example.com
+`
+	tests := []struct {
+		name     string
+		contents string // or "" for no file
+		store    CredentialStore
+		devKeys  map[string]string // credential name -> key, after reading
+		backup   bool
+		err      string
+	}{
+		{"missing file", "", memStore{}, nil, false, ""},
+		{"version 0", v0, memStore{}, map[string]string{DevKeyName("dev"): "dk-dev"}, true, ""},
+		{"version 0 without profiles", "configurations: {}\n", memStore{}, map[string]string{}, false, ""},
+		{"version 0 empty map", "{}", memStore{}, map[string]string{}, false, ""},
+		{"version 0 without developer keys", "configurations:\n  live:\n    endpoint: https://live.example.com\n", memStore{}, map[string]string{}, true, ""},
+		{"current version", "version: 1\nconfigurations: {}\n", memStore{}, map[string]string{}, false, ""},
+		{"newer version", "version: 2\nconfigurations: {}\n", memStore{}, nil, false, "upgrade ecom"},
+		{"migration fails", v0, failStore{memStore{}}, nil, true, "read-only"},
+		{"not YAML", "version: [\n", memStore{}, nil, false, "unmarshal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "ecomrc")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, ".ecomrc.yaml")
+			if tt.contents != "" {
+				if err := ioutil.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			data, err := readConfigFile(path, tt.store)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("readConfigFile error = %v, want one containing %q", err, tt.err)
+				}
+				// the file is left as it was
+				if got, _ := ioutil.ReadFile(path); string(got) != tt.contents {
+					t.Errorf("config file changed to %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readConfigFile: %v", err)
+			}
+
+			backup := path + ".v0.bak"
+			saved, err := ioutil.ReadFile(backup)
+			switch {
+			case tt.backup && err != nil:
+				t.Fatalf("no backup: %v", err)
+			case tt.backup && string(saved) != tt.contents:
+				t.Errorf("backup is %q, want the original %q", saved, tt.contents)
+			case !tt.backup && err == nil:
+				t.Errorf("unexpected backup %s", backup)
+			}
+			if tt.contents == "" {
+				if data != nil {
+					t.Errorf("got %q for a missing file, want nil", data)
+				}
+				return
+			}
+
+			var cfgs EcomConfigurations
+			if err := yaml.Unmarshal(data, &cfgs); err != nil {
+				t.Fatalf("unmarshal result: %v", err)
+			}
+			if cfgs.Version != ConfigVersion {
+				t.Errorf("version is %d, want %d", cfgs.Version, ConfigVersion)
+			}
+			if strings.Contains(string(data), "developer-key") {
+				t.Errorf("developer key left in the config:\n%s", data)
+			}
+			written, _ := ioutil.ReadFile(path)
+			if string(written) != string(data) {
+				t.Errorf("config file is\n%s\nwant the upgraded contents\n%s", written, data)
+			}
+			mem := tt.store.(memStore)
+			if len(mem) != len(tt.devKeys) {
+				t.Errorf("credential store has %d entries, want %d", len(mem), len(tt.devKeys))
+			}
+			for name, key := range tt.devKeys {
+				if string(mem[name]) != key {
+					t.Errorf("credential %s is %q, want %q", name, mem[name], key)
+				}
+			}
+		})
+	}
+}
+
+func TestReadConfigFileKeepsProfiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ecomrc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, ".ecomrc.yaml")
+	contents := `configurations:
+  dev:
+    endpoint: https://dev.example.com
+    developer-key: dk-dev
+`
+	if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal(err)
+	}
+	data, err := readConfigFile(path, memStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cfgs EcomConfigurations
+	if err := yaml.Unmarshal(data, &cfgs); err != nil {
+		t.Fatal(err)
+	}
+	e, ok := cfgs.Configurations["dev"]
+	if !ok || e.Endpoint != "https://dev.example.com" {
+		t.Errorf("profile dev is %+v, want its endpoint kept", e)
+	}
+
+	// reading again leaves the upgraded file alone
+	again, err := readConfigFile(path, memStore{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(data) {
+		t.Errorf("second read returned\n%s\nwant\n%s", again, data)
+	}
+}
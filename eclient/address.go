@@ -74,20 +74,14 @@ func (c *EcomClient) CreateAddress(ctx context.Context, req *CreateAddressReques
 	url := fmt.Sprintf("%s/addresses", c.endpoint)
 	body := strings.NewReader(string(request))
 
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("%w: client decode", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var address Address
@@ -101,23 +95,14 @@ func (c *EcomClient) CreateAddress(ctx context.Context, req *CreateAddressReques
 // by id.
 func (c *EcomClient) GetAddress(ctx context.Context, addrID string) (*Address, error) {
 	url := fmt.Sprintf("%s/addresses/%s", c.endpoint, addrID)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("decode: %w", err)
-		}
-		if e.Code == "addresses/address-not-found" {
-			return nil, ErrAddressNotFound
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrAddressNotFound)
 	}
 
 	var v Address
@@ -138,12 +123,16 @@ func (c *EcomClient) GetAddressesByUser(ctx context.Context, userID string) ([]*
 		Path:     "addresses",
 		RawQuery: v.Encode(),
 	}
-	res, err := c.request(http.MethodGet, url.String(), nil)
+	res, err := c.request(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container AddressListContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("decode: %w", err)
@@ -161,21 +150,14 @@ func (c *EcomClient) UpdateAddress(ctx context.Context, addrID string, req *Upda
 
 	body := strings.NewReader(string(request))
 	url := c.endpoint + "/addresses/" + addrID
-	res, err := c.request(http.MethodPatch, url, body)
+	res, err := c.request(ctx, http.MethodPatch, url, body)
 	if err != nil {
-		return nil, errors.Wrapf(err, "c.request(http.MethodPatch, uri=%q, body)", url)
+		return nil, errors.Wrapf(err, "c.request(ctx, http.MethodPatch, uri=%q, body)", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "addresses/address-not-found" {
-			return nil, ErrAddressNotFound
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrAddressNotFound)
 	}
 
 	var v Address
@@ -188,23 +170,14 @@ func (c *EcomClient) UpdateAddress(ctx context.Context, addrID string, req *Upda
 // DeleteAddress calls the API service to attempt to delete an address.
 func (c *EcomClient) DeleteAddress(ctx context.Context, addrID string) error {
 	url := fmt.Sprintf("%s/addresses/%s", c.endpoint, addrID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return fmt.Errorf("decode: %w", err)
-		}
-		if e.Code == "addresses/address-not-found" {
-			return ErrAddressNotFound
-		}
-		return fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrAddressNotFound)
 	}
 	return nil
 }
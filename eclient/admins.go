@@ -1,6 +1,7 @@
 package eclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -15,7 +16,7 @@ type createAdminRequest struct {
 }
 
 // CreateAdmin calls the API Service to create a new administrator.
-func (c *EcomClient) CreateAdmin(email, passwd, first, last string) (*UserResponse, error) {
+func (c *EcomClient) CreateAdmin(ctx context.Context, email, passwd, first, last string) (*UserResponse, error) {
 	p := createAdminRequest{
 		Email:  email,
 		Passwd: passwd,
@@ -27,14 +28,14 @@ func (c *EcomClient) CreateAdmin(email, passwd, first, last string) (*UserRespon
 		return nil, fmt.Errorf("create admin failed: %w", err)
 	}
 	uri := c.endpoint + "/admins"
-	res, err := c.request(http.MethodPost, uri, strings.NewReader(string(payload)))
+	res, err := c.request(ctx, http.MethodPost, uri, strings.NewReader(string(payload)))
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP POST to %q return %s", uri, res.Status)
+		return nil, apiError(res, nil)
 	}
 	user := UserResponse{}
 	err = json.NewDecoder(res.Body).Decode(&user)
@@ -45,14 +46,18 @@ func (c *EcomClient) CreateAdmin(email, passwd, first, last string) (*UserRespon
 }
 
 // ListAdmins calls the API Service to get all administrators.
-func (c *EcomClient) ListAdmins() ([]*UserResponse, error) {
+func (c *EcomClient) ListAdmins(ctx context.Context) ([]*UserResponse, error) {
 	uri := c.endpoint + "/admins"
-	res, err := c.request(http.MethodGet, uri, nil)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	users := make([]*UserResponse, 0, 8)
 	err = json.NewDecoder(res.Body).Decode(&users)
 	if err != nil {
@@ -63,12 +68,16 @@ func (c *EcomClient) ListAdmins() ([]*UserResponse, error) {
 
 // DeleteAdmin calls the API service to delete an administrator with the
 // given UUID.
-func (c *EcomClient) DeleteAdmin(uuid string) error {
+func (c *EcomClient) DeleteAdmin(ctx context.Context, uuid string) error {
 	uri := c.endpoint + "/admins/" + uuid
-	res, err := c.request(http.MethodDelete, uri, nil)
+	res, err := c.request(ctx, http.MethodDelete, uri, nil)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return apiError(res, nil)
+	}
 	return nil
 }
@@ -59,20 +59,14 @@ type CartProductRequest struct {
 // CreateCart calls the API service to attempt to create a new shopping cart.
 func (c *EcomClient) CreateCart(ctx context.Context) (*Cart, error) {
 	url := fmt.Sprintf("%s/carts", c.endpoint)
-	res, err := c.request(http.MethodPost, url, nil)
+	res, err := c.request(ctx, http.MethodPost, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodPost, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var cart Cart
@@ -90,24 +84,14 @@ func (c *EcomClient) CartAddProduct(ctx context.Context, req *CartProductRequest
 		return nil, errors.Wrap(err, "encode")
 	}
 	url := fmt.Sprintf("%s/carts-products", c.endpoint)
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodPost, url=%q, body)", url)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "carts/cart-not-found" {
-			return nil, ErrCartNotFound
-		}
-		if e.Code == "carts/cart-product-exists" {
-			return nil, ErrCartProductExists
-		}
-		return nil, fmt.Errorf("status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
 	}
 
 	if res.StatusCode == 201 {
@@ -130,12 +114,16 @@ func (c *EcomClient) GetCartProducts(ctx context.Context, cartID string) ([]*Car
 		Path:     "carts-products",
 		RawQuery: v.Encode(),
 	}
-	res, err := c.request(http.MethodGet, url.String(), nil)
+	res, err := c.request(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url.String())
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrCartNotFound)
+	}
+
 	var container CartProductsContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, errors.Wrap(err, "decode")
@@ -156,21 +144,14 @@ func (c *EcomClient) UpdateCartProduct(ctx context.Context, cartProductID string
 		return nil, errors.Wrap(err, "encode")
 	}
 	url := fmt.Sprintf("%s/carts-products/%s", c.endpoint, cartProductID)
-	res, err := c.request(http.MethodPatch, url, body)
+	res, err := c.request(ctx, http.MethodPatch, url, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "request")
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "carts/cart-product-not-found" {
-			return nil, ErrCartProductNotFound
-		}
-		return nil, fmt.Errorf("status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrCartProductNotFound)
 	}
 
 	if res.StatusCode == 200 {
@@ -186,27 +167,16 @@ func (c *EcomClient) UpdateCartProduct(ctx context.Context, cartProductID string
 // CartsRemoveProduct calls the API service to attempt to remove a product from a cart.
 func (c *EcomClient) CartsRemoveProduct(ctx context.Context, cartProductID string) error {
 	url := fmt.Sprintf("%s/carts-products/%s", c.endpoint, cartProductID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return errors.Wrap(err, "request")
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "carts/cart-product-not-found" {
-			return ErrCartProductNotFound
-		}
-		return fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
-	}
-
-	if res.StatusCode == 204 {
-		return nil
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrCartProductNotFound)
 	}
-	return fmt.Errorf("unknown response status code %d", res.StatusCode)
+	return nil
 }
 
 // EmptyCartProducts calls the API service to attempt to empty all products
@@ -220,25 +190,14 @@ func (c *EcomClient) EmptyCartProducts(ctx context.Context, cartID string) error
 		Path:     "carts-products",
 		RawQuery: v.Encode(),
 	}
-	res, err := c.request(http.MethodDelete, url.String(), nil)
+	res, err := c.request(ctx, http.MethodDelete, url.String(), nil)
 	if err != nil {
 		return errors.Wrap(err, "request")
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "carts/cart-not-found" {
-			return ErrCartNotFound
-		}
-		return fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
-	}
-
-	if res.StatusCode == 204 {
-		return nil
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrCartNotFound)
 	}
-	return fmt.Errorf("unknown response status code %d", res.StatusCode)
+	return nil
 }
@@ -1,6 +1,7 @@
 package eclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -30,27 +31,16 @@ type Category struct {
 }
 
 // GetCategories returns a slice of categories.
-func (c *EcomClient) GetCategories() ([]*Category, error) {
+func (c *EcomClient) GetCategories(ctx context.Context) ([]*Category, error) {
 	uri := c.endpoint + "/categories"
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("http new request failed: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.jwt)
-	res, err := c.client.Do(req)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("http do to %v failed: %w", uri, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, fmt.Errorf("client decode error: %w", err)
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var categoryContainer CategoryContainer
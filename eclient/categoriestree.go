@@ -1,6 +1,7 @@
 package eclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -33,7 +34,7 @@ type CategoryTreeResponse struct {
 }
 
 // UpdateCategoriesTree calls the API Service to update the categories tree.
-func (c *EcomClient) UpdateCategoriesTree(cats *CategoryRequest) error {
+func (c *EcomClient) UpdateCategoriesTree(ctx context.Context, cats *CategoryRequest) error {
 	request, err := json.Marshal(&cats)
 	if err != nil {
 		return fmt.Errorf("json marshal: %w", err)
@@ -41,44 +42,29 @@ func (c *EcomClient) UpdateCategoriesTree(cats *CategoryRequest) error {
 
 	url := c.endpoint + "/categories-tree"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPut, url, body)
+	res, err := c.request(ctx, http.MethodPut, url, body)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return fmt.Errorf("decode: %w", err)
-		}
-		return fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return apiError(res, nil)
 	}
 	return nil
 }
 
 // GetCategoriesTree returns the categories tree
-func (c *EcomClient) GetCategoriesTree() (*CategoryTreeResponse, error) {
+func (c *EcomClient) GetCategoriesTree(ctx context.Context) (*CategoryTreeResponse, error) {
 	uri := c.endpoint + "/categories-tree"
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("http new request failed: %w", err)
-	}
-
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.jwt)
-	res, err := c.client.Do(req)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("http do to %v failed: %w", uri, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, fmt.Errorf("client decode error: %w", err)
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var tree CategoryTreeResponse
@@ -89,23 +75,15 @@ func (c *EcomClient) GetCategoriesTree() (*CategoryTreeResponse, error) {
 }
 
 // PurgeCatalog calls the API Service to purge the entire catalog.
-func (c *EcomClient) PurgeCatalog() error {
+func (c *EcomClient) PurgeCatalog(ctx context.Context) error {
 	uri := c.endpoint + "/categories"
-	res, err := c.request(http.MethodDelete, uri, nil)
+	res, err := c.request(ctx, http.MethodDelete, uri, nil)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 	if res.StatusCode >= 400 {
-		var e struct {
-			Status  int    `json:"status"`
-			Code    string `json:"code"`
-			Message string `json:"message"`
-		}
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return fmt.Errorf("client decode error: %w", err)
-		}
-		return fmt.Errorf("%s: %w", e.Message, err)
+		return apiError(res, nil)
 	}
 	return nil
 }
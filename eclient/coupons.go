@@ -49,22 +49,14 @@ func (c *EcomClient) CreateCoupon(ctx context.Context, req *CreateCouponRequest)
 
 	url := fmt.Sprintf("%s/coupons", c.endpoint)
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "request")
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		return nil, errors.Wrapf(err,
-			"status: %d, code: %s, message: %s: %v",
-			e.Status, e.Code, e.Message, err)
+		return nil, apiError(res, nil)
 	}
 
 	var coupon Coupon
@@ -77,12 +69,16 @@ func (c *EcomClient) CreateCoupon(ctx context.Context, req *CreateCouponRequest)
 // GetCoupons calls the API service to attempt to retrieve all coupons.
 func (c *EcomClient) GetCoupons(ctx context.Context) ([]*Coupon, error) {
 	url := fmt.Sprintf("%s/coupons", c.endpoint)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container couponsListContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, errors.Wrap(err, "decode failed")
@@ -94,25 +90,14 @@ func (c *EcomClient) GetCoupons(ctx context.Context) ([]*Coupon, error) {
 // with the given id.
 func (c *EcomClient) DeleteCoupon(ctx context.Context, couponID string) error {
 	url := fmt.Sprintf("%s/coupons/%s", c.endpoint, couponID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return errors.Wrapf(err, "delete request url=%q", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "coupons/coupon-not-found" {
-			return ErrCouponNotFound
-		}
-		return errors.Wrapf(err,
-			"status: %d, code: %s, message: %s: %v",
-			e.Status, e.Code, e.Message, err)
+		return apiError(res, ErrCouponNotFound)
 	}
 	return nil
 }
@@ -130,25 +115,14 @@ func (c *EcomClient) VoidCoupon(ctx context.Context, couponID string) error {
 
 	body := strings.NewReader(string(request))
 	url := fmt.Sprintf("%s/coupons/%s", c.endpoint, couponID)
-	res, err := c.request(http.MethodPatch, url, body)
+	res, err := c.request(ctx, http.MethodPatch, url, body)
 	if err != nil {
 		return errors.Wrapf(err, "patch request url=%q", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "coupons/coupon-not-found" {
-			return ErrCouponNotFound
-		}
-		return errors.Wrapf(err,
-			"status: %d, code: %s, message: %s: %v",
-			e.Status, e.Code, e.Message, err)
+		return apiError(res, ErrCouponNotFound)
 	}
 	return nil
 }
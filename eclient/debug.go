@@ -0,0 +1,181 @@
+package eclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const redacted = "REDACTED"
+
+// secretFields are JSON object keys, form fields and query parameters
+// whose values are never written to the debug trace.
+var secretFields = map[string]bool{
+	"key":           true,
+	"developer-key": true,
+	"custom_token":  true,
+	"token":         true,
+	"idToken":       true,
+	"id_token":      true,
+	"refreshToken":  true,
+	"refresh_token": true,
+	"password":      true,
+}
+
+// maxDebugBody is the number of bytes of a non-JSON body written to the
+// debug trace.
+const maxDebugBody = 4096
+
+// SetHTTPDebug installs a logging RoundTripper that writes the method,
+// URL, headers, status, latency and bodies of every request and response
+// to w. Bearer tokens and developer keys are redacted.
+func (c *EcomClient) SetHTTPDebug(w io.Writer) {
+	next := c.client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	c.client.Transport = &debugTransport{next: next, w: w}
+}
+
+type debugTransport struct {
+	next http.RoundTripper
+
+	mu sync.Mutex // serialises writes so concurrent traces do not interleave
+	w  io.Writer
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = ioutil.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	res, err := t.next.RoundTrip(req)
+	latency := time.Since(start).Round(time.Millisecond)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "--> %s %s\n", req.Method, redactURL(req.URL))
+	writeHeaders(&b, req.Header)
+	writeBody(&b, req.Header.Get("Content-Type"), reqBody)
+
+	if err != nil {
+		fmt.Fprintf(&b, "<-- %s %s error after %s: %v\n\n", req.Method, redactURL(req.URL), latency, err)
+		t.write(b.Bytes())
+		return res, err
+	}
+
+	resBody, rerr := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	fmt.Fprintf(&b, "<-- %s %s %s (%s)\n", res.Status, req.Method, redactURL(req.URL), latency)
+	writeHeaders(&b, res.Header)
+	writeBody(&b, res.Header.Get("Content-Type"), resBody)
+	if rerr != nil {
+		fmt.Fprintf(&b, "(error reading body: %v)\n", rerr)
+	}
+	b.WriteString("\n")
+	t.write(b.Bytes())
+	return res, nil
+}
+
+func (t *debugTransport) write(p []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.w.Write(p)
+}
+
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if len(q) == 0 {
+		return u.String()
+	}
+	for k := range q {
+		if secretFields[k] {
+			q.Set(k, redacted)
+		}
+	}
+	c := *u
+	c.RawQuery = q.Encode()
+	return c.String()
+}
+
+func writeHeaders(w io.Writer, h http.Header) {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		v := strings.Join(h[k], ", ")
+		if k == "Authorization" {
+			if i := strings.IndexByte(v, ' '); i > 0 {
+				v = v[:i+1] + redacted
+			} else {
+				v = redacted
+			}
+		}
+		fmt.Fprintf(w, "%s: %s\n", k, v)
+	}
+}
+
+func writeBody(w io.Writer, contentType string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if v, err := url.ParseQuery(string(body)); err == nil {
+			for k := range v {
+				if secretFields[k] {
+					v.Set(k, redacted)
+				}
+			}
+			fmt.Fprintf(w, "%s\n", v.Encode())
+			return
+		}
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		if out, err := json.MarshalIndent(redactJSON(v), "", "  "); err == nil {
+			fmt.Fprintf(w, "%s\n", out)
+			return
+		}
+	}
+
+	if len(body) > maxDebugBody {
+		fmt.Fprintf(w, "%s\n... (%d bytes truncated)\n", body[:maxDebugBody], len(body)-maxDebugBody)
+		return
+	}
+	fmt.Fprintf(w, "%s\n", body)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, e := range t {
+			if _, ok := e.(string); ok && secretFields[k] {
+				t[k] = redacted
+				continue
+			}
+			t[k] = redactJSON(e)
+		}
+	case []interface{}:
+		for i, e := range t {
+			t[i] = redactJSON(e)
+		}
+	}
+	return v
+}
@@ -0,0 +1,90 @@
+package eclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"no secrets", `{"sku":"A","name":"Apple"}`, `{"name":"Apple","sku":"A"}`},
+		{"top level", `{"key":"k","idToken":"t","email":"e"}`, `{"email":"e","idToken":"REDACTED","key":"REDACTED"}`},
+		{"nested", `{"user":{"password":"p","refreshToken":"r"}}`, `{"user":{"password":"REDACTED","refreshToken":"REDACTED"}}`},
+		{"in arrays", `[{"token":"t"},{"developer-key":"d"}]`, `[{"token":"REDACTED"},{"developer-key":"REDACTED"}]`},
+		{"non-string secrets kept", `{"key":{"id":1},"token":null}`, `{"key":{"id":1},"token":null}`},
+		{"scalar", `"token"`, `"token"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v interface{}
+			if err := json.Unmarshal([]byte(tt.in), &v); err != nil {
+				t.Fatal(err)
+			}
+			out, err := json.Marshal(redactJSON(v))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("redactJSON(%s) = %s, want %s", tt.in, out, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"https://api.example.com/products", "https://api.example.com/products"},
+		{"https://api.example.com/products?limit=2", "https://api.example.com/products?limit=2"},
+		{"https://securetoken.googleapis.com/v1/token?key=secret", "https://securetoken.googleapis.com/v1/token?key=REDACTED"},
+		{"https://example.com/x?b=2&token=t&a=1", "https://example.com/x?a=1&b=2&token=REDACTED"},
+	}
+	for _, tt := range tests {
+		u, err := url.Parse(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := redactURL(u); got != tt.want {
+			t.Errorf("redactURL(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+		if u.String() != tt.in {
+			t.Errorf("redactURL modified its argument: %s", u)
+		}
+	}
+}
+
+func TestWriteHeadersRedactsAuthorization(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"Bearer abc.def.ghi", "Authorization: Bearer REDACTED\n"},
+		{"abc", "Authorization: REDACTED\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		writeHeaders(&b, http.Header{"Authorization": []string{tt.value}})
+		if b.String() != tt.want {
+			t.Errorf("writeHeaders(Authorization: %s) wrote %q, want %q", tt.value, b.String(), tt.want)
+		}
+	}
+}
+
+func TestWriteBodyRedactsForms(t *testing.T) {
+	var b bytes.Buffer
+	writeBody(&b, "application/x-www-form-urlencoded",
+		[]byte("grant_type=refresh_token&refresh_token=secret"))
+	want := "grant_type=refresh_token&refresh_token=REDACTED\n"
+	if b.String() != want {
+		t.Errorf("writeBody wrote %q, want %q", b.String(), want)
+	}
+}
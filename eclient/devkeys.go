@@ -45,20 +45,14 @@ func (c *EcomClient) CreateDeveloperKey(ctx context.Context, d *DevKeyRequest) (
 
 	url := c.endpoint + "/developer-keys"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("decode: %w", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var devKey DevKeyResponse
@@ -79,12 +73,16 @@ func (c *EcomClient) GetDeveloperKeys(ctx context.Context, userID string) ([]*De
 		Path:     "developer-keys",
 		RawQuery: v.Encode(),
 	}
-	res, err := c.request(http.MethodGet, url.String(), nil)
+	res, err := c.request(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container DevKeysContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("decode: %w", err)
@@ -96,23 +94,14 @@ func (c *EcomClient) GetDeveloperKeys(ctx context.Context, userID string) ([]*De
 // key by id.
 func (c *EcomClient) DeleteDeveloperKey(ctx context.Context, devKeyID string) error {
 	url := fmt.Sprintf("%s/developer-keys/%s", c.endpoint, devKeyID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return errors.Wrapf(err, "request failed url=%q", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "developer-keys/developer-key-not-found" {
-			return ErrDeveloperKeyNotFound
-		}
-		return fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return apiError(res, ErrDeveloperKeyNotFound)
 	}
 	return nil
 }
@@ -2,13 +2,16 @@ package eclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -19,8 +22,6 @@ import (
 // Version string
 var Version string
 
-var userAgent string
-
 // EcomClient structure.
 type EcomClient struct {
 	endpoint string
@@ -28,7 +29,24 @@ type EcomClient struct {
 	hostname string
 	port     string
 	client   *http.Client
-	jwt      string
+	retry    RetryPolicy
+	logger   *log.Logger
+
+	userAgent          string
+	secureTokenURL     string
+	identityToolkitURL string
+
+	// mu guards jwt and refreshToken, which are replaced when the ID
+	// token is refreshed part way through a command.
+	mu           sync.Mutex
+	jwt          string
+	refreshToken string
+
+	// refreshMu serialises token refreshes so concurrent requests that
+	// fail with a 401 trigger a single exchange.
+	refreshMu sync.Mutex
+	cfg       *configmgr.EcomConfigEntry
+	apiKey    string
 }
 
 type sysInfoPg struct {
@@ -94,8 +112,9 @@ type tokenAndCustomerResponse struct {
 
 var timeout = time.Duration(10 * time.Second)
 
-// New creates an EcomClient struct for interacting with the API Service
-func New(endpoint string) *EcomClient {
+// New creates an EcomClient struct for interacting with the API Service.
+// It returns an error if endpoint is not an absolute http or https URL.
+func New(endpoint string, opts ...Option) (*EcomClient, error) {
 	tr := &http.Transport{
 		MaxIdleConnsPerHost: 10,
 	}
@@ -106,24 +125,60 @@ func New(endpoint string) *EcomClient {
 
 	url, err := url.Parse(endpoint)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%+v\n", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("parse endpoint %q: %w", endpoint, err)
+	}
+	if (url.Scheme != "http" && url.Scheme != "https") || url.Host == "" {
+		return nil, fmt.Errorf("endpoint %q is not an http or https URL", endpoint)
 	}
 
-	return &EcomClient{
-		endpoint: endpoint,
-		scheme: url.Scheme,
-		hostname: url.Host,
-		port: url.Port(),
-		client:   client,
+	c := &EcomClient{
+		endpoint:           endpoint,
+		scheme:             url.Scheme,
+		hostname:           url.Host,
+		port:               url.Port(),
+		client:             client,
+		retry:              DefaultRetryPolicy,
+		userAgent:          fmt.Sprintf("ecom/%s", Version),
+		secureTokenURL:     DefaultSecureTokenURL,
+		identityToolkitURL: DefaultIdentityToolkitURL,
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
+	return c, nil
 }
 
 // SetJWT sets the current Firebase JWT for future calls to the e-commerce API.
 func (c *EcomClient) SetJWT(jwt string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.jwt = jwt
 }
 
+// JWT returns the current Firebase JWT, which changes if the token is
+// refreshed.
+func (c *EcomClient) JWT() string {
+	return c.token()
+}
+
+func (c *EcomClient) token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.jwt
+}
+
+// SetLogger sets a logger for diagnostic messages such as retried
+// requests. A nil logger, the default, discards them.
+func (c *EcomClient) SetLogger(l *log.Logger) {
+	c.logger = l
+}
+
+func (c *EcomClient) logf(format string, v ...interface{}) {
+	if c.logger != nil {
+		c.logger.Printf(format, v...)
+	}
+}
+
 // See https://firebase.google.com/docs/reference/rest/auth/#section-verify-custom-token
 // token	      string   A Firebase Auth custom token from which to create an ID and refresh token pair.
 // returnSecureToken  boolean  Whether or not to return an ID and refresh token. Should always be true.
@@ -157,48 +212,191 @@ type exchangeRefreshTokenResponse struct {
 	ProjectID    string `json:"project_id"`
 }
 
-type badRequestResponse struct {
-	Status  int    `json:"status"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
+// SetToken accepts an EcomConfigEntry and derives the name of its token
+// and refresh token in the credential store, before reading it,
+// inspecting it and if necessary generating a refresh token, before
+// writing back the pair. The token is then stored in the EcomClient
+// struct. If the API Service later rejects the token with a 401, it is
+// refreshed and written back in the same way and the request is
+// replayed once.
+func (c *EcomClient) SetToken(ctx context.Context, cfg *configmgr.EcomConfigEntry) error {
+	name, err := configmgr.TokenName(cfg)
+	if err != nil {
+		return err
+	}
+	tar, err := configmgr.ReadTokenAndRefreshToken(name)
+	if err != nil {
+		return fmt.Errorf("token and refresh token cannot be read from %q: %w", name, err)
+	}
+	c.refreshMu.Lock()
+	c.cfg = cfg
+	c.refreshMu.Unlock()
+	c.mu.Lock()
+	c.jwt = tar.IDToken
+	c.refreshToken = tar.RefreshToken
+	c.mu.Unlock()
+
+	claims, err := ParseTokenClaims(tar.IDToken)
+	if err != nil {
+		return fmt.Errorf("token %q: %w", name, err)
+	}
+
+	// If the token has expired, use the refresh token to get another
+	if !claims.ExpiresAt.After(time.Now()) {
+		return c.refresh(ctx, tar.IDToken)
+	}
+	return nil
+}
+
+// TokenClaims are the claims of a Firebase ID token.
+type TokenClaims struct {
+	UID       string    `json:"uid"`
+	Role      string    `json:"role,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	IssuedAt  time.Time `json:"issued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// firebaseClaims are the claims of a Firebase ID token as encoded in the
+// JWT. The role is a custom claim set by the API Service.
+type firebaseClaims struct {
+	jwt.StandardClaims
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+	Email  string `json:"email"`
+}
+
+// ParseTokenClaims decodes the claims of a Firebase ID token. The
+// signature is not verified; the API Service does that.
+func ParseTokenClaims(idToken string) (*TokenClaims, error) {
+	var p jwt.Parser
+	var fc firebaseClaims
+	if _, _, err := p.ParseUnverified(idToken, &fc); err != nil {
+		return nil, fmt.Errorf("parse id token failed: %w", err)
+	}
+	uid := fc.UserID
+	if uid == "" {
+		uid = fc.Subject
+	}
+	return &TokenClaims{
+		UID:       uid,
+		Role:      fc.Role,
+		Email:     fc.Email,
+		IssuedAt:  time.Unix(fc.IssuedAt, 0),
+		ExpiresAt: time.Unix(fc.ExpiresAt, 0),
+	}, nil
+}
+
+// Claims returns the claims of the current ID token.
+func (c *EcomClient) Claims() (*TokenClaims, error) {
+	return ParseTokenClaims(c.token())
 }
 
-// SetToken accepts an EcomConfigEntry and derives the token and refresh
-// token file, before reading it, inspecting it and if necessary generating
-// a refresh token, before writing back the file. The token is then stored
-// in the EcomClient struct.
-func (c *EcomClient) SetToken(cfg *configmgr.EcomConfigEntry) error {
-	file, err := configmgr.TokenFilename(cfg)
+// Refresh exchanges the refresh token for a new ID token whether or not
+// the current one has expired, and persists the pair as when the token
+// is refreshed after a 401.
+func (c *EcomClient) Refresh(ctx context.Context) error {
+	return c.refresh(ctx, c.token())
+}
+
+// SignInEphemeral signs in with the developer key and keeps the ID and
+// refresh tokens in the client only. Nothing is read from or written to
+// a token file, including when the ID token is refreshed.
+func (c *EcomClient) SignInEphemeral(ctx context.Context, devKey string) (*UserResponse, error) {
+	g, err := c.GetConfig(ctx)
 	if err != nil {
-		return fmt.Errorf("token file %q not found: %w", file, err)
+		return nil, err
 	}
-	tar, err := configmgr.ReadTokenAndRefreshToken(file)
+	customToken, user, err := c.SignInWithDevKey(ctx, devKey)
 	if err != nil {
-		return fmt.Errorf("token and refresh token cannot be read from %q: %w", file, err)
+		return nil, err
 	}
-	var p jwt.Parser
-	t, _, err := p.ParseUnverified(tar.IDToken, &jwt.StandardClaims{})
-	claims := t.Claims.(*jwt.StandardClaims)
-	utcNow := time.Now().Unix()
+	tar, err := c.ExchangeCustomTokenForIDAndRefreshToken(ctx, g.APIKEY, customToken)
+	if err != nil {
+		return nil, err
+	}
+	c.refreshMu.Lock()
+	c.cfg = nil
+	c.apiKey = g.APIKEY
+	c.refreshMu.Unlock()
+	c.mu.Lock()
+	c.jwt = tar.IDToken
+	c.refreshToken = tar.RefreshToken
+	c.mu.Unlock()
+	return user, nil
+}
 
-	// If the token has expired, use the refresh token to get another
-	if claims.ExpiresAt-utcNow <= 0 {
-		f, err := c.GetConfig()
+// SignIn signs in with the developer key of cfg and writes the ID and
+// refresh tokens to the credential store under TokenName(cfg), as
+// SetToken expects to find them.
+func (c *EcomClient) SignIn(ctx context.Context, cfg *configmgr.EcomConfigEntry) (*UserResponse, error) {
+	name, err := configmgr.TokenName(cfg)
+	if err != nil {
+		return nil, err
+	}
+	user, err := c.SignInEphemeral(ctx, cfg.DevKey)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	tar := configmgr.TokenAndRefreshToken{
+		IDToken:      c.jwt,
+		RefreshToken: c.refreshToken,
+	}
+	c.mu.Unlock()
+	if err := configmgr.WriteTokenAndRefreshToken(name, &tar); err != nil {
+		return nil, fmt.Errorf("write token and refresh token failed: %w", err)
+	}
+	c.refreshMu.Lock()
+	c.cfg = cfg
+	c.refreshMu.Unlock()
+	return user, nil
+}
+
+// refresh exchanges the refresh token for a new ID token and persists
+// the pair to the profile's token file, unless the client was signed in
+// with SignInEphemeral. stale is the ID token that was
+// found to be expired; if another request has already replaced it, the
+// exchange is skipped.
+func (c *EcomClient) refresh(ctx context.Context, stale string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.mu.Lock()
+	current, refreshToken := c.jwt, c.refreshToken
+	c.mu.Unlock()
+	if current != stale {
+		return nil
+	}
+	if refreshToken == "" {
+		return errors.New("no refresh token available")
+	}
+
+	if c.apiKey == "" {
+		f, err := c.GetConfig(ctx)
 		if err != nil {
 			return err
 		}
-		tar, err = c.ExchangeRefreshTokenForIDToken(f.APIKEY, tar.RefreshToken)
+		c.apiKey = f.APIKEY
+	}
+	tar, err := c.ExchangeRefreshTokenForIDToken(ctx, c.apiKey, refreshToken)
+	if err != nil {
+		return fmt.Errorf("exchange refresh token for id token failed: %w", err)
+	}
+	if c.cfg != nil {
+		filename, err := configmgr.TokenName(c.cfg)
 		if err != nil {
-			return fmt.Errorf("exchange refresh token for id token failed: %w", err)
+			return err
 		}
-		hostname, err := configmgr.URLToHostName(cfg.Endpoint)
-		filename := fmt.Sprintf("%s-%s", hostname, cfg.DevKey[:6])
-		err = configmgr.WriteTokenAndRefreshToken(filename, tar)
-		if err != nil {
+		if err = configmgr.WriteTokenAndRefreshToken(filename, tar); err != nil {
 			return fmt.Errorf("write token and refresh token failed: %w", err)
 		}
 	}
+
+	c.mu.Lock()
 	c.jwt = tar.IDToken
+	c.refreshToken = tar.RefreshToken
+	c.mu.Unlock()
 	return nil
 }
 
@@ -211,16 +409,10 @@ func (c *EcomClient) SetToken(cfg *configmgr.EcomConfigEntry) error {
 // id_token	string	A Firebase Auth ID token.
 // user_id	string	The uid corresponding to the provided ID token.
 // project_id	string	Your Firebase project ID.
-func (c *EcomClient) ExchangeRefreshTokenForIDToken(firebaseAPIKey, refreshToken string) (*configmgr.TokenAndRefreshToken, error) {
+func (c *EcomClient) ExchangeRefreshTokenForIDToken(ctx context.Context, firebaseAPIKey, refreshToken string) (*configmgr.TokenAndRefreshToken, error) {
 	v := url.Values{}
 	v.Set("key", firebaseAPIKey)
-	uri := url.URL{
-		Scheme:     "https",
-		Host:       "securetoken.googleapis.com",
-		Path:       "v1/token",
-		ForceQuery: false,
-		RawQuery:   v.Encode(),
-	}
+	uri := c.secureTokenURL + "/v1/token?" + v.Encode()
 	reqBody := exchangeRefreshTokenRequest{
 		GrantType:    "refresh_token",
 		RefreshToken: refreshToken,
@@ -228,24 +420,25 @@ func (c *EcomClient) ExchangeRefreshTokenForIDToken(firebaseAPIKey, refreshToken
 	payload := url.Values{}
 	payload.Set("grant_type", reqBody.GrantType)
 	payload.Set("refresh_token", reqBody.RefreshToken)
-	req, err := http.NewRequest("POST", uri.String(), strings.NewReader(payload.Encode()))
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(payload.Encode()))
+		if err != nil {
+			return nil, fmt.Errorf("create new POST request failed: %w", err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("User-Agent", c.userAgent)
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create new POST request failed: %w", err)
+		return nil, fmt.Errorf("token exchange request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		body, _ := ioutil.ReadAll(res.Body)
-		err = json.Unmarshal(body, &e)
-		if err != nil {
-			return nil, fmt.Errorf("%s %s: %w", e.Code, e.Message, err)
-		}
+		return nil, apiError(res, nil)
 	}
+
 	response := exchangeRefreshTokenResponse{}
 	err = json.NewDecoder(res.Body).Decode(&response)
 	if err != nil {
@@ -258,17 +451,11 @@ func (c *EcomClient) ExchangeRefreshTokenForIDToken(firebaseAPIKey, refreshToken
 }
 
 // ExchangeCustomTokenForIDAndRefreshToken calls the Firebase REST API to exchange a customer token for Firebase token and refresh token.
-func (c *EcomClient) ExchangeCustomTokenForIDAndRefreshToken(firebaseAPIKey, token string) (*configmgr.TokenAndRefreshToken, error) {
+func (c *EcomClient) ExchangeCustomTokenForIDAndRefreshToken(ctx context.Context, firebaseAPIKey, token string) (*configmgr.TokenAndRefreshToken, error) {
 	// build the URL including Query params
 	v := url.Values{}
 	v.Set("key", firebaseAPIKey)
-	uri := url.URL{
-		Scheme:     "https",
-		Host:       "www.googleapis.com",
-		Path:       "identitytoolkit/v3/relyingparty/verifyCustomToken",
-		ForceQuery: false,
-		RawQuery:   v.Encode(),
-	}
+	uri := c.identityToolkitURL + "/identitytoolkit/v3/relyingparty/verifyCustomToken?" + v.Encode()
 
 	// build and execute the request
 	reqBody := verifyCustomTokenRequest{
@@ -277,39 +464,23 @@ func (c *EcomClient) ExchangeCustomTokenForIDAndRefreshToken(firebaseAPIKey, tok
 	}
 	buf := new(bytes.Buffer)
 	json.NewEncoder(buf).Encode(reqBody)
-	req, err := http.NewRequest("POST", uri.String(), buf)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	if err != nil {
-		return nil, err
-	}
-	client := &http.Client{}
-	res, err := client.Do(req)
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+		return req, nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("creating new POST request failed: %w", err)
+		return nil, fmt.Errorf("verify custom token request failed: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 400 {
-		var badReqRes struct {
-			Error struct {
-				Code    int64  `json:"code"`
-				Message string `json:"message"`
-				Errors  []struct {
-					Message string `json:"message"`
-					Domain  string `json:"domain"`
-					Reason  string `json:"reason"`
-				} `json:"errors"`
-				Status string `json:"status"`
-			} `json:"error"`
-		}
-		err = json.NewDecoder(res.Body).Decode(&badReqRes)
-		if err != nil {
-			return nil, fmt.Errorf("decode failed: %w", err)
-		}
-		return nil, fmt.Errorf("%d %s", badReqRes.Error.Code, badReqRes.Error.Message)
-	} else if res.StatusCode > 400 {
-		return nil, fmt.Errorf("%s", res.Status)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
 	}
 
 	tokenResponse := verifyCustomTokenResponse{}
@@ -323,9 +494,67 @@ func (c *EcomClient) ExchangeCustomTokenForIDAndRefreshToken(firebaseAPIKey, tok
 	}, nil
 }
 
+type verifyPasswordRequest struct {
+	Email             string `json:"email"`
+	Password          string `json:"password"`
+	ReturnSecureToken bool   `json:"returnSecureToken"`
+}
+
+type verifyPasswordResponse struct {
+	Kind         string `json:"kind"`
+	LocalID      string `json:"localId"`
+	Email        string `json:"email"`
+	IDToken      string `json:"idToken"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    string `json:"expiresIn"`
+}
+
+// SignInWithPassword calls the Firebase REST API to sign in with an email
+// and password, returning an ID token and refresh token.
+// https://www.googleapis.com/identitytoolkit/v3/relyingparty/verifyPassword?key=[API_KEY]
+func (c *EcomClient) SignInWithPassword(ctx context.Context, firebaseAPIKey, email, password string) (*configmgr.TokenAndRefreshToken, error) {
+	v := url.Values{}
+	v.Set("key", firebaseAPIKey)
+	uri := c.identityToolkitURL + "/identitytoolkit/v3/relyingparty/verifyPassword?" + v.Encode()
+
+	buf := new(bytes.Buffer)
+	json.NewEncoder(buf).Encode(verifyPasswordRequest{
+		Email:             email,
+		Password:          password,
+		ReturnSecureToken: true,
+	})
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify password request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
+	var response verifyPasswordResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("json decode failed: %w", err)
+	}
+	return &configmgr.TokenAndRefreshToken{
+		IDToken:      response.IDToken,
+		RefreshToken: response.RefreshToken,
+	}, nil
+}
+
 // SignInWithDevKey exchanges a Developer Key for a Customer token.
 // https://www.googleapis.com/identitytoolkit/v3/relyingparty/verifyCustomToken?key=[API_KEY]
-func (c *EcomClient) SignInWithDevKey(key string) (token string, user *UserResponse, err error) {
+func (c *EcomClient) SignInWithDevKey(ctx context.Context, key string) (token string, user *UserResponse, err error) {
 	uri := c.endpoint + "/signin-with-devkey"
 	payload := devKeyRequest{
 		Key: key,
@@ -333,20 +562,14 @@ func (c *EcomClient) SignInWithDevKey(key string) (token string, user *UserRespo
 	buf := new(bytes.Buffer)
 	json.NewEncoder(buf).Encode(payload)
 
-	req, err := http.NewRequest("POST", uri, buf)
-	if err != nil {
-		return "", nil, fmt.Errorf("error creating new POST request: %w", err)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	res, err := c.client.Do(req)
+	res, err := c.request(ctx, http.MethodPost, uri, buf)
 	if err != nil {
 		return "", nil, fmt.Errorf("error executing HTTP POST to %v : %w", uri, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		return "", nil, fmt.Errorf("%s", res.Status)
+		return "", nil, apiError(res, nil)
 	}
 
 	ct := tokenAndCustomerResponse{}
@@ -358,23 +581,16 @@ func (c *EcomClient) SignInWithDevKey(key string) (token string, user *UserRespo
 }
 
 // SysInfo retrieves the System Info from the API endpoint.
-func (c *EcomClient) SysInfo() (*SysInfo, error) {
+func (c *EcomClient) SysInfo(ctx context.Context) (*SysInfo, error) {
 	uri := c.endpoint + "/sysinfo"
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("http new request failed: %w", err)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.jwt)
-	req.Header.Set("User-Agent", fmt.Sprintf("ecom/%s", Version))
-	res, err := c.client.Do(req)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("HTTP GET to %v failed: %w", uri, err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf("%s", res.Status)
+		return nil, apiError(res, nil)
 	}
 
 	var sysInfo SysInfo
@@ -404,21 +620,87 @@ type FirebaseConfigResponse struct {
 // GetConfig gets the Firebase Config from the server.
 // HTTP GET /config is a public resource and requires no
 // authorization or token.
-func (c *EcomClient) GetConfig() (*FirebaseConfigResponse, error) {
+func (c *EcomClient) GetConfig(ctx context.Context) (*FirebaseConfigResponse, error) {
 	uri := c.endpoint + "/config"
-	req, err := http.NewRequest("GET", uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("http new request failed: %w", err)
-	}
-	req.Header.Set("Accept", "application/json")
-	res, err := c.client.Do(req)
+	res, err := c.send(ctx, http.MethodGet, uri, nil, "")
 	if err != nil {
 		return nil, fmt.Errorf("http do to %v failed: %w", uri, err)
 	}
 	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
 	var f *ConfigContainerResponse
 	if err := json.NewDecoder(res.Body).Decode(&f); err != nil {
 		return nil, errors.Wrapf(err, "json decode url %s", uri)
 	}
 	return f.FirebaseConfig, nil
 }
+
+// request builds and executes an HTTP request against the API Service.
+// The request is bound to ctx so cancellation and deadlines abort it, in
+// addition to the client's own per-request timeout. Transient failures
+// are retried according to the client's RetryPolicy.
+func (c *EcomClient) request(ctx context.Context, method, uri string, body io.Reader) (*http.Response, error) {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = ioutil.ReadAll(body); err != nil {
+			return nil, fmt.Errorf("read HTTP %s request body: %w", method, err)
+		}
+	}
+
+	idToken := c.token()
+	res, err := c.send(ctx, method, uri, data, idToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// The ID token expires after an hour, so long running commands
+	// refresh it and replay the request once.
+	if res.StatusCode == http.StatusUnauthorized && idToken != "" && c.canRefresh() {
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+		c.logf("%s %s: %s; refreshing token and retrying", method, uri, res.Status)
+		if err := c.refresh(ctx, idToken); err != nil {
+			return nil, fmt.Errorf("refresh token after HTTP %s %s: %w", method, uri, err)
+		}
+		return c.send(ctx, method, uri, data, c.token())
+	}
+	return res, nil
+}
+
+// send executes a single request, including any retries, authorised
+// with idToken unless it is empty. A nil data means no request body.
+func (c *EcomClient) send(ctx context.Context, method, uri string, data []byte, idToken string) (*http.Response, error) {
+	res, err := c.do(ctx, func() (*http.Request, error) {
+		var body io.Reader
+		if data != nil {
+			body = bytes.NewReader(data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, uri, body)
+		if err != nil {
+			return nil, fmt.Errorf("new HTTP %s request: %w", method, err)
+		}
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("User-Agent", c.userAgent)
+		if idToken != "" {
+			req.Header.Set("Authorization", "Bearer "+idToken)
+		}
+		if data != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("do HTTP %s request: %w", method, err)
+	}
+	return res, nil
+}
+
+func (c *EcomClient) canRefresh() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refreshToken != ""
+}
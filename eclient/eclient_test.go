@@ -0,0 +1,27 @@
+package eclient
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		ok       bool
+	}{
+		{"https://api.example.com", true},
+		{"http://localhost:8080/", true},
+		{"api.example.com", false},
+		{"ftp://api.example.com", false},
+		{"https://", false},
+		{"://bad", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		c, err := New(tt.endpoint)
+		if tt.ok != (err == nil) {
+			t.Errorf("New(%q) error = %v, want ok %t", tt.endpoint, err, tt.ok)
+		}
+		if tt.ok && c == nil {
+			t.Errorf("New(%q) returned a nil client", tt.endpoint)
+		}
+	}
+}
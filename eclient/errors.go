@@ -0,0 +1,147 @@
+package eclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError using errors.Is according to the
+// HTTP status code of the response.
+var (
+	// ErrBadRequest is returned on 400 status code
+	ErrBadRequest = errors.New("eclient: bad request")
+
+	// ErrUnauthorized is returned on 401 and 403 status codes.
+	ErrUnauthorized = errors.New("eclient: unauthorized")
+
+	// ErrNotFound is returned on 404 status code.
+	ErrNotFound = errors.New("eclient: not found")
+
+	// ErrConflict is returned on 409 status code.
+	ErrConflict = errors.New("eclient: conflict")
+)
+
+// codeErrors maps API error codes to the resource specific sentinel
+// errors they satisfy.
+var codeErrors = map[string]error{
+	"addresses/address-not-found":                ErrAddressNotFound,
+	"carts/cart-not-found":                       ErrCartNotFound,
+	"carts/cart-product-exists":                  ErrCartProductExists,
+	"carts/cart-product-not-found":               ErrCartProductNotFound,
+	"coupons/coupon-not-found":                   ErrCouponNotFound,
+	"developer-keys/developer-key-not-found":     ErrDeveloperKeyNotFound,
+	"inventory/inventory-not-found":              ErrInventoryNotFound,
+	"offers/offer-exists":                        ErrOfferExists,
+	"offers/offer-not-found":                     ErrOfferNotFound,
+	"orders/order-not-found":                     ErrOrderNotFound,
+	"price-lists/price-list-code-exists":         ErrPriceListCodeExists,
+	"price-lists/price-list-not-found":           ErrPriceListNotFound,
+	"promo-rules/promo-rule-not-found":           ErrPromoRuleNotFound,
+	"shipping-tariffs/shipping-tariff-not-found": ErrShippingTariffNotFound,
+	"webhooks/event-type-not-found":              ErrEventTypeNotFound,
+	"webhooks/webhook-exists":                    ErrWebhookExists,
+	"webhooks/webhook-not-found":                 ErrWebhookNotFound,
+}
+
+// APIError is returned by every EcomClient method when the API Service
+// (or the Firebase identity service) responds with an error status.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Method     string
+	URL        string
+	RequestID  string
+
+	// resource is the resource specific sentinel error, if any, that this
+	// error satisfies in addition to the status code sentinels.
+	resource error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: status: %d", e.Method, e.URL, e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, ", code: %s", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ", message: %s", e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request id %s)", e.RequestID)
+	}
+	return b.String()
+}
+
+// Is reports whether the error matches target. Status code sentinels
+// (ErrBadRequest, ErrUnauthorized, ErrNotFound and ErrConflict) and the
+// resource specific sentinels such as ErrProductNotFound are supported.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	}
+	return e.resource != nil && e.resource == target
+}
+
+// apiError consumes the body of an error response and returns an
+// *APIError describing it. If notFound is non-nil, a 404 response also
+// matches it.
+func apiError(res *http.Response, notFound error) *APIError {
+	e := APIError{
+		StatusCode: res.StatusCode,
+		RequestID:  res.Header.Get("X-Request-Id"),
+	}
+	if e.RequestID == "" {
+		e.RequestID = res.Header.Get("X-Cloud-Trace-Context")
+	}
+	if res.Request != nil {
+		e.Method = res.Request.Method
+		e.URL = res.Request.URL.String()
+	}
+
+	// The API Service responds with { status, code, message } whereas the
+	// Google identity services wrap their errors in { error: {...} }.
+	var body struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+		Error   *struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	data, _ := ioutil.ReadAll(res.Body)
+	if err := json.Unmarshal(data, &body); err == nil {
+		e.Code = body.Code
+		e.Message = body.Message
+		if body.Error != nil {
+			e.Code = body.Error.Status
+			e.Message = body.Error.Message
+		}
+	} else {
+		e.Message = strings.TrimSpace(string(data))
+		if len(e.Message) > 200 {
+			e.Message = e.Message[:200] + "..."
+		}
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(res.StatusCode)
+	}
+
+	if sentinel, ok := codeErrors[e.Code]; ok {
+		e.resource = sentinel
+	} else if notFound != nil && res.StatusCode == http.StatusNotFound {
+		e.resource = notFound
+	}
+	return &e
+}
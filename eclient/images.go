@@ -1,6 +1,7 @@
 package eclient
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -31,8 +32,14 @@ type ImageResponse struct {
 	Modified    time.Time `json:"modified"`
 }
 
+// ImageContainer is a container for a list of images.
+type ImageContainer struct {
+	Object string           `json:"object"`
+	Data   []*ImageResponse `json:"data"`
+}
+
 // CreateImage calls the API service to create an image for a given product.
-func (c *EcomClient) CreateImage(image ImageRequest) (*ImageResponse, error) {
+func (c *EcomClient) CreateImage(ctx context.Context, image ImageRequest) (*ImageResponse, error) {
 	request, err := json.Marshal(&image)
 	if err != nil {
 		return nil, fmt.Errorf("client: json marshal failed: %w", err)
@@ -42,18 +49,14 @@ func (c *EcomClient) CreateImage(image ImageRequest) (*ImageResponse, error) {
 	params.Add("product_id", image.ProductID)
 	uri := c.endpoint + "/images?" + params.Encode()
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, uri, body)
+	res, err := c.request(ctx, http.MethodPost, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, fmt.Errorf("client decode error: %w", err)
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var response ImageResponse
@@ -65,23 +68,43 @@ func (c *EcomClient) CreateImage(image ImageRequest) (*ImageResponse, error) {
 
 // DeleteProductImages calls the API Service to delete all
 // images for a given product id.
-func (c *EcomClient) DeleteProductImages(productID string) error {
+func (c *EcomClient) DeleteProductImages(ctx context.Context, productID string) error {
 	params := url.Values{}
 	params.Add("product_id", productID)
 
 	uri := c.endpoint + "/images?" + params.Encode()
-	res, err := c.request(http.MethodDelete, uri, nil)
+	res, err := c.request(ctx, http.MethodDelete, uri, nil)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return fmt.Errorf("client decode error: %w", err)
-		}
-		return fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return apiError(res, nil)
 	}
 	return nil
 }
+
+// GetProductImages calls the API Service to get the images of a given
+// product id.
+func (c *EcomClient) GetProductImages(ctx context.Context, productID string) ([]*ImageResponse, error) {
+	params := url.Values{}
+	params.Add("product_id", productID)
+
+	uri := c.endpoint + "/images?" + params.Encode()
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
+	var container ImageContainer
+	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
+		return nil, fmt.Errorf("response decode failed: %w", err)
+	}
+	return container.Data, nil
+}
@@ -45,7 +45,7 @@ type InventoryBatchUpdateContainer struct {
 	Data   []*InventoryBatchUpdateRequest `json:"data"`
 }
 
-//InventoryBatchUpdateRequest JSON batch inventory update request.
+// InventoryBatchUpdateRequest JSON batch inventory update request.
 type InventoryBatchUpdateRequest struct {
 	ProductID   *string `json:"product_id"`
 	Onhand      *int    `json:"onhand"`
@@ -56,7 +56,7 @@ type InventoryBatchUpdateRequest struct {
 // by id.
 func (c *EcomClient) GetInventory(ctx context.Context, invID string) (*Inventory, error) {
 	url := fmt.Sprintf("%s/inventory/%s", c.endpoint, invID)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err,
 			"request(http.MethodGet, url=%q, nil)", url)
@@ -64,17 +64,7 @@ func (c *EcomClient) GetInventory(ctx context.Context, invID string) (*Inventory
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "inventory/inventory-not-found" {
-			return nil, ErrInventoryNotFound
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrInventoryNotFound)
 	}
 
 	var v Inventory
@@ -87,19 +77,52 @@ func (c *EcomClient) GetInventory(ctx context.Context, invID string) (*Inventory
 // GetAllInventory calls the API service to retrieve all inventory
 // for all products.
 func (c *EcomClient) GetAllInventory(ctx context.Context) ([]*Inventory, error) {
-	url := fmt.Sprintf("%s/inventory", c.endpoint)
-	res, err := c.request(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err,
-			"request(http.MethodGet, url=%q, nil)", url)
+	return c.Inventory(nil).All(ctx)
+}
+
+// InventoryIterator iterates over inventory for all products, fetching a page at a time.
+type InventoryIterator struct {
+	p   *pager
+	buf []*Inventory
+}
+
+// Inventory returns an iterator over inventory for all products. opts may be nil.
+func (c *EcomClient) Inventory(opts *ListOptions) *InventoryIterator {
+	return &InventoryIterator{p: newPager(c, c.endpoint+"/inventory", opts)}
+}
+
+// Next returns the next inventory. It returns ErrDone when there are no
+// more.
+func (it *InventoryIterator) Next(ctx context.Context) (*Inventory, error) {
+	for len(it.buf) == 0 {
+		if err := it.p.fetch(ctx, &it.buf); err != nil {
+			return nil, err
+		}
+		if n := len(it.buf); n > 0 {
+			it.p.cursor(it.buf[n-1].ID)
+		}
 	}
-	defer res.Body.Close()
+	if err := it.p.take(); err != nil {
+		return nil, err
+	}
+	v := it.buf[0]
+	it.buf = it.buf[1:]
+	return v, nil
+}
 
-	var container InventoryContainer
-	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
-		return nil, errors.Wrap(err, "decode failed")
+// All returns the remaining inventory for all products.
+func (it *InventoryIterator) All(ctx context.Context) ([]*Inventory, error) {
+	list := make([]*Inventory, 0, 16)
+	for {
+		v, err := it.Next(ctx)
+		if err == ErrDone {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
 	}
-	return container.Data, nil
 }
 
 // UpdateInventory calls the API service to update an individual inventory.
@@ -111,7 +134,7 @@ func (c *EcomClient) UpdateInventory(ctx context.Context, invID string, req *Upd
 
 	body := bytes.NewReader(request)
 	url := fmt.Sprintf("%s/inventory/%s", c.endpoint, invID)
-	res, err := c.request(http.MethodPatch, url, body)
+	res, err := c.request(ctx, http.MethodPatch, url, body)
 	if err != nil {
 		return nil, errors.Wrapf(err,
 			"request(http.MethodPatch, url=%q, nil)", url)
@@ -119,13 +142,7 @@ func (c *EcomClient) UpdateInventory(ctx context.Context, invID string, req *Upd
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "client decode")
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrInventoryNotFound)
 	}
 
 	var v Inventory
@@ -148,7 +165,7 @@ func (c *EcomClient) UpdateInventoryBatch(ctx context.Context, inv []*InventoryB
 	}
 	body := bytes.NewReader(b)
 	url := fmt.Sprintf("%s/inventory:batch-update", c.endpoint)
-	res, err := c.request(http.MethodPatch, url, body)
+	res, err := c.request(ctx, http.MethodPatch, url, body)
 	if err != nil {
 		return nil, errors.Wrapf(err,
 			"request(http.MethodPatch, url=%q, body=%q)", url, string(b))
@@ -156,13 +173,7 @@ func (c *EcomClient) UpdateInventoryBatch(ctx context.Context, inv []*InventoryB
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrapf(err, "client decode")
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var container InventoryContainer
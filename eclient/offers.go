@@ -41,14 +41,13 @@ type CreateOfferRequest struct {
 // CreateOffer calls the API service to active an offer using the given
 // promo rule id.
 func (c *EcomClient) CreateOffer(ctx context.Context, req *CreateOfferRequest) (*Offer, error) {
-	fmt.Printf("%+v\n", req.PromoRuleID)
 	request, err := json.Marshal(&req)
 	if err != nil {
 		return nil, errors.Wrapf(err, "json marshal")
 	}
 	body := bytes.NewReader(request)
 	url := fmt.Sprintf("%s/offers", c.endpoint)
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, errors.Wrapf(err,
 			"request(http.MethodPost, url=%q, body=%q)",
@@ -56,19 +55,8 @@ func (c *EcomClient) CreateOffer(ctx context.Context, req *CreateOfferRequest) (
 	}
 	defer res.Body.Close()
 
-	fmt.Println(res.StatusCode)
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "offers/offer-exists" {
-			return nil, ErrOfferExists
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var v Offer
@@ -81,24 +69,14 @@ func (c *EcomClient) CreateOffer(ctx context.Context, req *CreateOfferRequest) (
 // GetOffer calls the API service to get an individual offer.
 func (c *EcomClient) GetOffer(ctx context.Context, offerID string) (*Offer, error) {
 	url := fmt.Sprintf("%s/offers/%s", c.endpoint, offerID)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "offers/offer-not-found" {
-			return nil, ErrOfferNotFound
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrOfferNotFound)
 	}
 
 	var v Offer
@@ -111,21 +89,14 @@ func (c *EcomClient) GetOffer(ctx context.Context, offerID string) (*Offer, erro
 // GetOffers calls the API service to get a list of all active offers.
 func (c *EcomClient) GetOffers(ctx context.Context) ([]*Offer, error) {
 	url := fmt.Sprintf("%s/offers", c.endpoint)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
 	}
 
 	var container OfferContainer
@@ -137,25 +108,15 @@ func (c *EcomClient) GetOffers(ctx context.Context) ([]*Offer, error) {
 
 // DeleteOffer calls the API service to delete and offer by id.
 func (c *EcomClient) DeleteOffer(ctx context.Context, offerID string) error {
-	url := fmt.Sprintf("%s/webhooks/%s", c.endpoint, offerID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	url := fmt.Sprintf("%s/offers/%s", c.endpoint, offerID)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return errors.Wrapf(err, "request(http.MethodDelete, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "offers/offer-not-found" {
-			return ErrOfferNotFound
-		}
-		return fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrOfferNotFound)
 	}
 	return nil
 }
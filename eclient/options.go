@@ -0,0 +1,69 @@
+package eclient
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Default base URLs of the Google identity services used to exchange
+// tokens. The Firebase Auth emulator serves both beneath its own host,
+// for example http://localhost:9099/securetoken.googleapis.com.
+const (
+	DefaultSecureTokenURL     = "https://securetoken.googleapis.com"
+	DefaultIdentityToolkitURL = "https://www.googleapis.com"
+)
+
+// Option configures an EcomClient created by New.
+type Option func(*EcomClient)
+
+// WithTransport sets the http.RoundTripper used for all requests,
+// including those to the identity services.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *EcomClient) {
+		c.client.Transport = rt
+	}
+}
+
+// WithTimeout sets the per-request timeout. Zero means no timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *EcomClient) {
+		c.client.Timeout = d
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent to the API Service.
+func WithUserAgent(ua string) Option {
+	return func(c *EcomClient) {
+		c.userAgent = ua
+	}
+}
+
+// WithRetryPolicy sets the retry policy in place of DefaultRetryPolicy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *EcomClient) {
+		c.retry = p
+	}
+}
+
+// WithSecureTokenURL sets the base URL of the secure token service used
+// to exchange a refresh token for an ID token. An empty u keeps the
+// default.
+func WithSecureTokenURL(u string) Option {
+	return func(c *EcomClient) {
+		if u != "" {
+			c.secureTokenURL = strings.TrimSuffix(u, "/")
+		}
+	}
+}
+
+// WithIdentityToolkitURL sets the base URL of the identity toolkit
+// service used to exchange a custom token for an ID and refresh token.
+// An empty u keeps the default.
+func WithIdentityToolkitURL(u string) Option {
+	return func(c *EcomClient) {
+		if u != "" {
+			c.identityToolkitURL = strings.TrimSuffix(u, "/")
+		}
+	}
+}
@@ -94,7 +94,6 @@ type OrderAddressRequest struct {
 
 // PlaceOrder calls the API service to attempt to place an order
 func (c *EcomClient) PlaceOrder(ctx context.Context, o *OrderRequest) (*Order, error) {
-	fmt.Printf("%+v\n", o)
 	request, err := json.Marshal(&o)
 	if err != nil {
 		return nil, errors.Wrap(err, "json.Marshal")
@@ -102,20 +101,14 @@ func (c *EcomClient) PlaceOrder(ctx context.Context, o *OrderRequest) (*Order, e
 
 	body := strings.NewReader(string(request))
 	url := fmt.Sprintf("%s/orders", c.endpoint)
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
-		return nil, ErrCartNotFound
+		return nil, errors.Wrapf(err, "request(http.MethodPost, url=%q, body)", url)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var v Order
@@ -128,20 +121,14 @@ func (c *EcomClient) PlaceOrder(ctx context.Context, o *OrderRequest) (*Order, e
 // GetOrder calls the API service to return a single order by id.
 func (c *EcomClient) GetOrder(ctx context.Context, orderID string) (*Order, error) {
 	url := fmt.Sprintf("%s/orders/%s", c.endpoint, orderID)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return nil, ErrOrderNotFound
+		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrOrderNotFound)
 	}
 
 	var v Order
@@ -149,33 +136,54 @@ func (c *EcomClient) GetOrder(ctx context.Context, orderID string) (*Order, erro
 		return nil, errors.Wrap(err, "decode")
 	}
 	return &v, nil
-
 }
 
 // GetOrders calls the API service to return all orders.
 func (c *EcomClient) GetOrders(ctx context.Context) ([]*Order, error) {
-	url := fmt.Sprintf("%s/orders", c.endpoint)
-	res, err := c.request(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err,
-			"request(http.MethodGet, url=%q, nil)", url)
-	}
-	defer res.Body.Close()
+	return c.Orders(nil).All(ctx)
+}
 
-	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		//dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
+// OrderIterator iterates over orders, fetching a page at a time.
+type OrderIterator struct {
+	p   *pager
+	buf []*Order
+}
+
+// Orders returns an iterator over orders. opts may be nil.
+func (c *EcomClient) Orders(opts *ListOptions) *OrderIterator {
+	return &OrderIterator{p: newPager(c, c.endpoint+"/orders", opts)}
+}
+
+// Next returns the next order. It returns ErrDone when there are no
+// more.
+func (it *OrderIterator) Next(ctx context.Context) (*Order, error) {
+	for len(it.buf) == 0 {
+		if err := it.p.fetch(ctx, &it.buf); err != nil {
+			return nil, err
+		}
+		if n := len(it.buf); n > 0 {
+			it.p.cursor(it.buf[n-1].ID)
 		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
 	}
+	if err := it.p.take(); err != nil {
+		return nil, err
+	}
+	v := it.buf[0]
+	it.buf = it.buf[1:]
+	return v, nil
+}
 
-	var container OrderListContainer
-	if err = json.NewDecoder(res.Body).Decode(&container); err != nil {
-		return nil, errors.Wrap(err, "decode")
+// All returns the remaining orders.
+func (it *OrderIterator) All(ctx context.Context) ([]*Order, error) {
+	list := make([]*Order, 0, 16)
+	for {
+		v, err := it.Next(ctx)
+		if err == ErrDone {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
 	}
-	return container.Data, nil
 }
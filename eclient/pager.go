@@ -0,0 +1,161 @@
+package eclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrDone is returned by an iterator's Next method when there are no
+// more items.
+var ErrDone = errors.New("eclient: no more items in iterator")
+
+// ListOptions controls how list iterators page through a resource.
+type ListOptions struct {
+	// PageSize is the number of items requested per page. Zero leaves
+	// the page size to the API Service.
+	PageSize int
+
+	// Limit is the maximum number of items returned by the iterator.
+	// Zero means no limit.
+	Limit int
+}
+
+// Links holds the paging links of a list response.
+type Links struct {
+	Self string `json:"self,omitempty"`
+	Next string `json:"next,omitempty"`
+}
+
+// listContainer is the common envelope of list responses. The API
+// Service either provides a links.next URL or sets has_more, in which
+// case the next page starts after the ID of the last item.
+type listContainer struct {
+	Object  string          `json:"object"`
+	Data    json.RawMessage `json:"data"`
+	Links   *Links          `json:"links,omitempty"`
+	HasMore bool            `json:"has_more"`
+}
+
+// pager fetches successive pages of a list resource. Each iterator
+// wraps a pager and decodes the pages into its own item type.
+type pager struct {
+	c       *EcomClient
+	first   string
+	next    string
+	opts    ListOptions
+	seen    int
+	done    bool
+	hasMore bool
+	after   string // ID of the last item of the most recent page
+	used    string // starting_after cursor of the most recent request
+}
+
+func newPager(c *EcomClient, uri string, opts *ListOptions) *pager {
+	p := pager{c: c}
+	if opts != nil {
+		p.opts = *opts
+	}
+	if p.opts.PageSize > 0 {
+		uri = withQuery(uri, "limit", strconv.Itoa(p.opts.PageSize))
+	}
+	p.first, p.next = uri, uri
+	return &p
+}
+
+// fetch retrieves the next page and decodes its data into v, which must
+// be a pointer to a slice. It returns ErrDone once there are no more
+// pages or the limit has been reached.
+func (p *pager) fetch(ctx context.Context, v interface{}) error {
+	if p.done || p.limitReached() {
+		return ErrDone
+	}
+	if p.hasMore {
+		if p.after == "" || p.after == p.used {
+			return ErrDone
+		}
+		p.used = p.after
+		p.next = withQuery(p.first, "starting_after", p.after)
+		p.hasMore = false
+	}
+	uri := p.next
+	res, err := p.c.request(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 400 {
+		return apiError(res, nil)
+	}
+
+	var container listContainer
+	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
+		return fmt.Errorf("json decode url=%q: %w", uri, err)
+	}
+	if len(container.Data) > 0 {
+		if err := json.Unmarshal(container.Data, v); err != nil {
+			return fmt.Errorf("json decode data url=%q: %w", uri, err)
+		}
+	}
+
+	switch {
+	case container.Links != nil && container.Links.Next != "":
+		next, err := resolve(uri, container.Links.Next)
+		if err != nil {
+			return err
+		}
+		p.next = next
+	case container.HasMore:
+		p.hasMore = true
+	default:
+		p.done = true
+	}
+	return nil
+}
+
+// cursor records the ID of the last item of the page just fetched, used
+// when the API Service pages with has_more rather than links.
+func (p *pager) cursor(id string) {
+	p.after = id
+}
+
+// take accounts for an item being returned by the iterator.
+func (p *pager) take() error {
+	if p.limitReached() {
+		return ErrDone
+	}
+	p.seen++
+	return nil
+}
+
+func (p *pager) limitReached() bool {
+	return p.opts.Limit > 0 && p.seen >= p.opts.Limit
+}
+
+func withQuery(uri, key, value string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	q := u.Query()
+	q.Set(key, value)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func resolve(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", fmt.Errorf("parse url %q: %w", base, err)
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("parse next link %q: %w", ref, err)
+	}
+	return b.ResolveReference(r).String(), nil
+}
@@ -1,8 +1,8 @@
 package eclient
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -55,14 +55,18 @@ type AssocResponse struct {
 }
 
 // GetProductCategoryRelations calls the API Service to get all catalog associations.
-func (c *EcomClient) GetProductCategoryRelations() ([]*ProductCategoryResponse, error) {
+func (c *EcomClient) GetProductCategoryRelations(ctx context.Context) ([]*ProductCategoryResponse, error) {
 	uri := c.endpoint + "/products-categories"
-	res, err := c.request(http.MethodGet, uri, nil)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container ProductCategoryContainerResponse
 	err = json.NewDecoder(res.Body).Decode(&container)
 	if err != nil {
@@ -73,7 +77,7 @@ func (c *EcomClient) GetProductCategoryRelations() ([]*ProductCategoryResponse,
 
 // UpdateProductCategoryRelations calls the API Service to update all
 // product to category relations.
-func (c *EcomClient) UpdateProductCategoryRelations(rels []*CreateProductsCategories) error {
+func (c *EcomClient) UpdateProductCategoryRelations(ctx context.Context, rels []*CreateProductsCategories) error {
 	container := CreateProductsCategoriesContainer{
 		Object: "list",
 		Data:   rels,
@@ -85,34 +89,30 @@ func (c *EcomClient) UpdateProductCategoryRelations(rels []*CreateProductsCatego
 	}
 	uri := c.endpoint + "/products-categories"
 	body := strings.NewReader(string(payload))
-	res, err := c.request(http.MethodPut, uri, body)
+	res, err := c.request(ctx, http.MethodPut, uri, body)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return fmt.Errorf("client decode error: %w", err)
-		}
-		return fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return apiError(res, nil)
 	}
 	return nil
 }
 
 // DeleteProductCategoryRelations calls the API Service to delete all
 // product to category relations.
-func (c *EcomClient) DeleteProductCategoryRelations() error {
+func (c *EcomClient) DeleteProductCategoryRelations(ctx context.Context) error {
 	uri := c.endpoint + "/products-categories"
-	res, err := c.request(http.MethodDelete, uri, nil)
+	res, err := c.request(ctx, http.MethodDelete, uri, nil)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		return errors.New("unauthorized")
+		return apiError(res, nil)
 	}
 	return nil
 }
@@ -44,24 +44,14 @@ func (c *EcomClient) CreatePPAGroup(ctx context.Context, g *CreatePAGroupRequest
 
 	uri := c.endpoint + "/products-assocs-groups"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, uri, body)
+	res, err := c.request(ctx, http.MethodPost, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return nil, ErrPPAssocGroupNotFound
-	}
-
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("client decode: %w", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var ppaGroup PPAssocGroupResponse
@@ -75,18 +65,14 @@ func (c *EcomClient) CreatePPAGroup(ctx context.Context, g *CreatePAGroupRequest
 // associations group.
 func (c *EcomClient) GetPPAGroup(ctx context.Context, ppaGroupID string) (*PPAssocGroupResponse, error) {
 	url := c.endpoint + "/products-assocs-groups/" + ppaGroupID
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 400 {
-		return nil, ErrBadRequest
-	}
-
-	if res.StatusCode == 404 {
-		return nil, ErrPPAssocGroupNotFound
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrPPAssocGroupNotFound)
 	}
 
 	var g PPAssocGroupResponse
@@ -99,12 +85,16 @@ func (c *EcomClient) GetPPAGroup(ctx context.Context, ppaGroupID string) (*PPAss
 // GetPPAGroups returns a list of all product to product association groups.
 func (c *EcomClient) GetPPAGroups(ctx context.Context) ([]*PPAssocGroupResponse, error) {
 	uri := c.endpoint + "/products-assocs-groups"
-	res, err := c.request(http.MethodGet, uri, nil)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container PPAssocGroupContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("decode: %w", err)
@@ -116,23 +106,14 @@ func (c *EcomClient) GetPPAGroups(ctx context.Context) ([]*PPAssocGroupResponse,
 // product associations group with the given ppaGroupID.
 func (c *EcomClient) DeletePPAGroup(ctx context.Context, ppaGroupID string) error {
 	url := c.endpoint + "/products-assocs-groups/" + ppaGroupID
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 204 {
-		return nil
-	}
-	if res.StatusCode == 400 {
-		return ErrBadRequest
-	}
-	if res.StatusCode == 404 {
-		return ErrPPAssocGroupNotFound
-	}
 	if res.StatusCode >= 400 {
-		return fmt.Errorf("err response %d", res.StatusCode)
+		return apiError(res, ErrPPAssocGroupNotFound)
 	}
 	return nil
 }
@@ -37,17 +37,21 @@ func (c *EcomClient) GetPPAssocs(ctx context.Context, ppaGroupID string) ([]*PPA
 	v.Set("pp_assoc_group_id", ppaGroupID)
 
 	url := url.URL{
-		Scheme:   "https",
+		Scheme:   c.scheme,
 		Host:     c.hostname,
 		Path:     "products-assocs",
 		RawQuery: v.Encode(),
 	}
-	res, err := c.request(http.MethodGet, url.String(), nil)
+	res, err := c.request(ctx, http.MethodGet, url.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrPPAssocGroupNotFound)
+	}
+
 	var container PPAssocsContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("decode: %w", err)
@@ -59,23 +63,14 @@ func (c *EcomClient) GetPPAssocs(ctx context.Context, ppaGroupID string) ([]*PPA
 // association
 func (c *EcomClient) DeletePPAssoc(ctx context.Context, ppAssocID string) error {
 	url := fmt.Sprintf("%s/products-assocs/%s", c.endpoint, ppAssocID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 204 {
-		return nil
-	}
-	if res.StatusCode == 400 {
-		return ErrBadRequest
-	}
-	if res.StatusCode == 404 {
-		return ErrPPAssocNotFound
-	}
 	if res.StatusCode >= 400 {
-		return fmt.Errorf("err response %d", res.StatusCode)
+		return apiError(res, ErrPPAssocNotFound)
 	}
 	return nil
 }
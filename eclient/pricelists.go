@@ -67,26 +67,14 @@ func (c *EcomClient) CreatePriceList(ctx context.Context, req *CreatePriceListRe
 	}
 	url := fmt.Sprintf("%s/price-lists", c.endpoint)
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, errors.Wrap(err, "request")
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "price-lists/price-list-not-found" {
-			return nil, ErrPriceListNotFound
-		}
-		if e.Code == "price-lists/price-list-code-exists" {
-			return nil, ErrPriceListCodeExists
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var v PriceList
@@ -100,21 +88,14 @@ func (c *EcomClient) CreatePriceList(ctx context.Context, req *CreatePriceListRe
 // GetPriceList calls the API service to get a price list by id.
 func (c *EcomClient) GetPriceList(ctx context.Context, priceListID string) (*PriceList, error) {
 	url := fmt.Sprintf("%s/price-lists/%s", c.endpoint, priceListID)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "price-lists/price-list-not-found" {
-			return nil, ErrPriceListNotFound
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrPriceListNotFound)
 	}
 
 	var v PriceList
@@ -127,12 +108,16 @@ func (c *EcomClient) GetPriceList(ctx context.Context, priceListID string) (*Pri
 // GetPriceLists returns a list of price lists.
 func (c *EcomClient) GetPriceLists(ctx context.Context) ([]*PriceList, error) {
 	url := fmt.Sprintf("%s/price-lists", c.endpoint)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.Wrapf(err, "request(http.MethodGet, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container PriceListContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, errors.Wrap(err, "decode")
@@ -148,7 +133,7 @@ func (c *EcomClient) UpdatePriceList(ctx context.Context, priceListID string, re
 	}
 	body := bytes.NewReader(request)
 	url := fmt.Sprintf("%s/price-lists/%s", c.endpoint, priceListID)
-	res, err := c.request(http.MethodPut, url, body)
+	res, err := c.request(ctx, http.MethodPut, url, body)
 	if err != nil {
 		return nil, errors.Wrapf(err,
 			"request(http.MethodPatch, url=%q, nil)", url)
@@ -156,19 +141,7 @@ func (c *EcomClient) UpdatePriceList(ctx context.Context, priceListID string, re
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, errors.Wrap(err, "decode")
-		}
-		if e.Code == "price-lists/price-list-not-found" {
-			return nil, ErrPriceListNotFound
-		}
-		if e.Code == "price-lists/price-list-code-exists" {
-			return nil, ErrPriceListCodeExists
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrPriceListNotFound)
 	}
 
 	var v PriceList
@@ -181,21 +154,14 @@ func (c *EcomClient) UpdatePriceList(ctx context.Context, priceListID string, re
 // DeletePriceList calls the API service to attempt to delete a price list by id.
 func (c *EcomClient) DeletePriceList(ctx context.Context, priceListID string) error {
 	url := fmt.Sprintf("%s/price-lists/%s", c.endpoint, priceListID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return errors.Wrapf(err, "request(http.MethodDelete, url=%q, nil)", url)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return errors.Wrap(err, "decode")
-		}
-		if e.Code == "price-lists/price-list-not-found" {
-			return ErrPriceListNotFound
-		}
-		return fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return apiError(res, ErrPriceListNotFound)
 	}
 	return nil
 }
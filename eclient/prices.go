@@ -46,7 +46,7 @@ type Price struct {
 }
 
 // SetPrices calls the API Service to update the categories tree.
-func (c *EcomClient) SetPrices(productID, priceListID string, prices []*PriceRequest) ([]*Price, error) {
+func (c *EcomClient) SetPrices(ctx context.Context, productID, priceListID string, prices []*PriceRequest) ([]*Price, error) {
 	container := PricesContainerRequest{
 		Object: "list",
 		Data:   prices,
@@ -63,18 +63,14 @@ func (c *EcomClient) SetPrices(productID, priceListID string, prices []*PriceReq
 
 	uri := c.endpoint + "/prices?" + params.Encode()
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPut, uri, body)
+	res, err := c.request(ctx, http.MethodPut, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, fmt.Errorf("client decode error: %w", err)
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var response PricesContainer
@@ -87,17 +83,50 @@ func (c *EcomClient) SetPrices(productID, priceListID string, prices []*PriceReq
 // GetPrices calls the API service to attempt to retrieve all prices
 // for all products.
 func (c *EcomClient) GetPrices(ctx context.Context) ([]*Price, error) {
-	url := fmt.Sprintf("%s/prices", c.endpoint)
-	res, err := c.request(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, errors.Wrapf(err,
-			"request(http.MethodGet, url=%q, nil)", url)
+	return c.Prices(nil).All(ctx)
+}
+
+// PriceIterator iterates over prices, fetching a page at a time.
+type PriceIterator struct {
+	p   *pager
+	buf []*Price
+}
+
+// Prices returns an iterator over prices. opts may be nil.
+func (c *EcomClient) Prices(opts *ListOptions) *PriceIterator {
+	return &PriceIterator{p: newPager(c, c.endpoint+"/prices", opts)}
+}
+
+// Next returns the next price. It returns ErrDone when there are no
+// more.
+func (it *PriceIterator) Next(ctx context.Context) (*Price, error) {
+	for len(it.buf) == 0 {
+		if err := it.p.fetch(ctx, &it.buf); err != nil {
+			return nil, err
+		}
+		if n := len(it.buf); n > 0 {
+			it.p.cursor(it.buf[n-1].ID)
+		}
 	}
-	defer res.Body.Close()
+	if err := it.p.take(); err != nil {
+		return nil, err
+	}
+	v := it.buf[0]
+	it.buf = it.buf[1:]
+	return v, nil
+}
 
-	var container PricesContainer
-	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
-		return nil, errors.Wrap(err, "decode")
+// All returns the remaining prices.
+func (it *PriceIterator) All(ctx context.Context) ([]*Price, error) {
+	list := make([]*Price, 0, 16)
+	for {
+		v, err := it.Next(ctx)
+		if err == ErrDone {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
 	}
-	return container.Data, nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strings"
 	"time"
@@ -72,7 +71,7 @@ type ProductPricing struct {
 }
 
 // CreateProduct calls the API to create a new product.
-func (c *EcomClient) CreateProduct(product *ProductRequest) (*ProductResponse, error) {
+func (c *EcomClient) CreateProduct(ctx context.Context, product *ProductRequest) (*ProductResponse, error) {
 	request, err := json.Marshal(&product)
 	if err != nil {
 		return nil, fmt.Errorf("client: json marshal failed: %w", err)
@@ -80,18 +79,14 @@ func (c *EcomClient) CreateProduct(product *ProductRequest) (*ProductResponse, e
 
 	uri := c.endpoint + "/products"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, uri, body)
+	res, err := c.request(ctx, http.MethodPost, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, fmt.Errorf("client decode error: %w", err)
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var pr ProductResponse
@@ -103,7 +98,7 @@ func (c *EcomClient) CreateProduct(product *ProductRequest) (*ProductResponse, e
 
 // ReplaceProduct calls the API Service creating a new product or, if
 // the product already exists updating it.
-func (c *EcomClient) ReplaceProduct(productID string, product *ProductRequest) (*ProductResponse, error) {
+func (c *EcomClient) ReplaceProduct(ctx context.Context, productID string, product *ProductRequest) (*ProductResponse, error) {
 	request, err := json.Marshal(&product)
 	if err != nil {
 		return nil, fmt.Errorf("update product productID=%s failed: %w", productID, err)
@@ -111,14 +106,14 @@ func (c *EcomClient) ReplaceProduct(productID string, product *ProductRequest) (
 
 	uri := c.endpoint + "/products/" + productID
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPut, uri, body)
+	res, err := c.request(ctx, http.MethodPut, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP PUT to %q return %s: %w", uri, res.Status, err)
+		return nil, apiError(res, nil)
 	}
 	var pr ProductResponse
 	if err = json.NewDecoder(res.Body).Decode(&pr); err != nil {
@@ -130,16 +125,14 @@ func (c *EcomClient) ReplaceProduct(productID string, product *ProductRequest) (
 // GetProduct calls the API Service to get a product by id.
 func (c *EcomClient) GetProduct(ctx context.Context, productID string) (*ProductResponse, error) {
 	url := c.endpoint + "/products/" + productID
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return nil, ErrProductNotFound
-	} else if res.StatusCode >= 400 {
-		return nil, fmt.Errorf("HTTP GET to %q returned %s: %w", url, res.Status, err)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrProductNotFound)
 	}
 	var p ProductResponse
 	if err := json.NewDecoder(res.Body).Decode(&p); err != nil {
@@ -150,39 +143,58 @@ func (c *EcomClient) GetProduct(ctx context.Context, productID string) (*Product
 
 // GetProducts returns a list of products
 func (c *EcomClient) GetProducts(ctx context.Context) ([]*ProductResponse, error) {
-	uri := c.endpoint + "/products"
-	res, err := c.request(http.MethodGet, uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer res.Body.Close()
+	return c.Products(nil).All(ctx)
+}
 
-	var container ProductContainerResponse
-	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
-		return nil, fmt.Errorf("get product response decode failed: %w", err)
-	}
-	return container.Data, nil
+// ProductIterator iterates over products, fetching a page at a time.
+type ProductIterator struct {
+	p   *pager
+	buf []*ProductResponse
+}
+
+// Products returns an iterator over products. opts may be nil.
+func (c *EcomClient) Products(opts *ListOptions) *ProductIterator {
+	return &ProductIterator{p: newPager(c, c.endpoint+"/products", opts)}
 }
 
-func (c *EcomClient) request(method, uri string, body io.Reader) (*http.Response, error) {
-	req, err := http.NewRequest(method, uri, body)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.jwt)
-	if method == http.MethodPost {
-		req.Header.Set("Content-Type", "application/json")
+// Next returns the next product. It returns ErrDone when there are no
+// more.
+func (it *ProductIterator) Next(ctx context.Context) (*ProductResponse, error) {
+	for len(it.buf) == 0 {
+		if err := it.p.fetch(ctx, &it.buf); err != nil {
+			return nil, err
+		}
+		if n := len(it.buf); n > 0 {
+			it.p.cursor(it.buf[n-1].ID)
+		}
 	}
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return nil, errors.Wrapf(err, "do HTTP %s request", req.Method)
+	if err := it.p.take(); err != nil {
+		return nil, err
+	}
+	v := it.buf[0]
+	it.buf = it.buf[1:]
+	return v, nil
+}
+
+// All returns the remaining products.
+func (it *ProductIterator) All(ctx context.Context) ([]*ProductResponse, error) {
+	list := make([]*ProductResponse, 0, 16)
+	for {
+		v, err := it.Next(ctx)
+		if err == ErrDone {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
 	}
-	return res, nil
 }
 
 // ProductExists returns true if the product with the SKU sku exists.
-func (c *EcomClient) ProductExists(sku string) (bool, error) {
+func (c *EcomClient) ProductExists(ctx context.Context, sku string) (bool, error) {
 	uri := c.endpoint + "/products/" + sku
-	res, err := c.request(http.MethodHead, uri, nil)
+	res, err := c.request(ctx, http.MethodHead, uri, nil)
 	if err != nil {
 		return false, fmt.Errorf("request failed: %w", err)
 	}
@@ -192,26 +204,23 @@ func (c *EcomClient) ProductExists(sku string) (bool, error) {
 	} else if res.StatusCode == 404 {
 		return false, nil
 	}
-	return true, fmt.Errorf("unknown HTTP status code %d: %w", res.StatusCode, err)
+	if res.StatusCode >= 400 {
+		return false, apiError(res, nil)
+	}
+	return true, nil
 }
 
 // DeleteProduct calls the API Service to delete a product by id.
 func (c *EcomClient) DeleteProduct(ctx context.Context, productID string) error {
 	url := fmt.Sprintf("%s/products/%s", c.endpoint, productID)
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 204 {
-		return nil
-	}
-	if res.StatusCode == 400 {
-		return ErrBadRequest
-	}
-	if res.StatusCode == 404 {
-		return ErrProductNotFound
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrProductNotFound)
 	}
 	return nil
 }
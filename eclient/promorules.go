@@ -10,9 +10,6 @@ import (
 	"time"
 )
 
-// ErrBadRequest is returned on 400 status code
-var ErrBadRequest = errors.New("eclient: bad request")
-
 // ErrPromoRuleNotFound 404
 var ErrPromoRuleNotFound = errors.New("eclient: promo rule not found")
 
@@ -70,20 +67,14 @@ func (c *EcomClient) CreatePromoRule(ctx context.Context, p *PromoRuleRequest) (
 
 	uri := c.endpoint + "/promo-rules"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, uri, body)
+	res, err := c.request(ctx, http.MethodPost, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request failed", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("%w: client decode error", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var promoRule PromoRule
@@ -96,24 +87,14 @@ func (c *EcomClient) CreatePromoRule(ctx context.Context, p *PromoRuleRequest) (
 // GetPromoRule returns a single promo rule
 func (c *EcomClient) GetPromoRule(ctx context.Context, promoRuleID string) (*PromoRule, error) {
 	url := fmt.Sprintf("%s/promo-rules/%s", c.endpoint, promoRuleID)
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode >= 400 && res.StatusCode < 500 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("decode: %w", err)
-		}
-
-		if e.Code == "promo-rules/promo-rule-not-found" {
-			return nil, ErrPromoRuleNotFound
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrPromoRuleNotFound)
 	}
 
 	var p PromoRule
@@ -126,12 +107,16 @@ func (c *EcomClient) GetPromoRule(ctx context.Context, promoRuleID string) (*Pro
 // GetPromoRules returns a list of all promo rules.
 func (c *EcomClient) GetPromoRules(ctx context.Context) ([]*PromoRule, error) {
 	uri := c.endpoint + "/promo-rules"
-	res, err := c.request(http.MethodGet, uri, nil)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container PromoRulesContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("decode: %w", err)
@@ -142,20 +127,14 @@ func (c *EcomClient) GetPromoRules(ctx context.Context) ([]*PromoRule, error) {
 // DeletePromoRule deletes a promo rule by id
 func (c *EcomClient) DeletePromoRule(ctx context.Context, id string) error {
 	uri := c.endpoint + "/promo-rules/" + id
-	res, err := c.request(http.MethodDelete, uri, nil)
+	res, err := c.request(ctx, http.MethodDelete, uri, nil)
 	if err != nil {
 		return fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 204 {
-		return nil
-	}
-	if res.StatusCode == 400 {
-		return ErrBadRequest
-	}
-	if res.StatusCode == 404 {
-		return ErrPromoRuleNotFound
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrPromoRuleNotFound)
 	}
 	return nil
 }
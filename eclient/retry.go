@@ -0,0 +1,152 @@
+package eclient
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+func init() {
+	rand.Seed(time.Now().UnixNano())
+}
+
+// RetryPolicy controls how requests that fail with a transient error are
+// retried. Transient errors are network errors and 429, 502, 503 and 504
+// responses.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the
+	// first. A value of 1 or less disables retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It doubles on each
+	// subsequent attempt up to MaxBackoff, with full jitter applied.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// RetryNonIdempotent allows POST and PATCH requests to be retried.
+	// Only set it when replaying the request cannot cause a duplicate,
+	// for example a batch update that sets absolute values.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is the policy used by clients returned from New.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// SetRetryPolicy replaces the retry policy used for future calls.
+func (c *EcomClient) SetRetryPolicy(p RetryPolicy) {
+	c.retry = p
+}
+
+// RetryPolicy returns the retry policy currently in use.
+func (c *EcomClient) RetryPolicy() RetryPolicy {
+	return c.retry
+}
+
+func (p RetryPolicy) allows(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// backoff returns the delay before retrying after the given attempt. A
+// Retry-After header on res takes precedence, capped at MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if d, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if d > p.MaxBackoff {
+				d = p.MaxBackoff
+			}
+			return d
+		}
+	}
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an
+// HTTP date.
+func retryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+func transient(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// do executes the request built by newReq, rebuilding and retrying it
+// according to the client's retry policy. newReq is called once per
+// attempt so that the request body can be replayed.
+func (c *EcomClient) do(ctx context.Context, newReq func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		res, err := c.client.Do(req)
+		if ctx.Err() != nil || attempt >= c.retry.MaxAttempts ||
+			!c.retry.allows(req.Method) || !transient(res, err) {
+			return res, err
+		}
+
+		wait := c.retry.backoff(attempt, res)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = res.Status
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		c.logf("%s %s: %s; retrying in %s (attempt %d of %d)",
+			req.Method, req.URL, reason, wait.Round(time.Millisecond),
+			attempt+1, c.retry.MaxAttempts)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package eclient
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{
+		MaxAttempts: 10,
+		MinBackoff:  100 * time.Millisecond,
+		MaxBackoff:  time.Second,
+	}
+	withRetryAfter := func(v string) *http.Response {
+		return &http.Response{Header: http.Header{"Retry-After": []string{v}}}
+	}
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		res      *http.Response
+		min, max time.Duration
+	}{
+		{"first retry", p, 1, nil, 0, 100 * time.Millisecond},
+		{"doubles", p, 2, nil, 0, 200 * time.Millisecond},
+		{"doubles again", p, 3, nil, 0, 400 * time.Millisecond},
+		{"capped", p, 8, nil, 0, time.Second},
+		{"no backoff", RetryPolicy{MaxAttempts: 3}, 2, nil, 0, 0},
+		{"response without Retry-After", p, 1, &http.Response{Header: http.Header{}}, 0, 100 * time.Millisecond},
+		{"Retry-After seconds", p, 1, withRetryAfter("1"), time.Second, time.Second},
+		{"Retry-After zero", p, 3, withRetryAfter("0"), 0, 0},
+		{"Retry-After capped", p, 1, withRetryAfter("120"), time.Second, time.Second},
+		{"Retry-After date in the past", p, 1, withRetryAfter("Mon, 02 Jan 2006 15:04:05 GMT"), 0, 0},
+		{"Retry-After invalid", p, 2, withRetryAfter("soon"), 0, 200 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the delay is jittered, so check it stays within bounds
+			for i := 0; i < 100; i++ {
+				d := tt.policy.backoff(tt.attempt, tt.res)
+				if d < tt.min || d > tt.max {
+					t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	future := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	tests := []struct {
+		value    string
+		ok       bool
+		min, max time.Duration
+	}{
+		{"", false, 0, 0},
+		{"30", true, 30 * time.Second, 30 * time.Second},
+		{"-1", false, 0, 0},
+		{"soon", false, 0, 0},
+		{"Mon, 02 Jan 2006 15:04:05 GMT", true, 0, 0},
+		{future, true, 59 * time.Minute, time.Hour},
+	}
+	for _, tt := range tests {
+		d, ok := retryAfter(tt.value)
+		if ok != tt.ok || d < tt.min || d > tt.max {
+			t.Errorf("retryAfter(%q) = %s, %t; want between %s and %s, %t",
+				tt.value, d, ok, tt.min, tt.max, tt.ok)
+		}
+	}
+}
+
+func TestRetryPolicyAllows(t *testing.T) {
+	tests := []struct {
+		method        string
+		nonIdempotent bool
+		want          bool
+	}{
+		{http.MethodGet, false, true},
+		{http.MethodPut, false, true},
+		{http.MethodDelete, false, true},
+		{http.MethodPost, false, false},
+		{http.MethodPatch, false, false},
+		{http.MethodPost, true, true},
+	}
+	for _, tt := range tests {
+		p := RetryPolicy{RetryNonIdempotent: tt.nonIdempotent}
+		if got := p.allows(tt.method); got != tt.want {
+			t.Errorf("RetryPolicy{RetryNonIdempotent: %t}.allows(%s) = %t, want %t",
+				tt.nonIdempotent, tt.method, got, tt.want)
+		}
+	}
+}
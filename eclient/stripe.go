@@ -18,20 +18,14 @@ type stripeCheckoutResponseBody struct {
 // for a given order.
 func (c *EcomClient) StripeCheckout(ctx context.Context, orderID string) (string, error) {
 	url := fmt.Sprintf("%s/orders/%s/stripecheckout", c.endpoint, orderID)
-	res, err := c.request(http.MethodPost, url, nil)
+	res, err := c.request(ctx, http.MethodPost, url, nil)
 	if err != nil {
-		return "", errors.Wrapf(err, "c.request(http.MethodPost, url=%q, nil)", url)
+		return "", errors.Wrapf(err, "c.request(ctx, http.MethodPost, url=%q, nil)", url)
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return "", errors.Wrap(err, "decode")
-		}
-		return "", fmt.Errorf("status: %d, code: %s, message: %s",
-			e.Status, e.Code, e.Message)
+		return "", apiError(res, ErrOrderNotFound)
 	}
 
 	var v stripeCheckoutResponseBody
@@ -51,20 +51,14 @@ func (c *EcomClient) CreateShippingTariff(ctx context.Context, req *CreateShippi
 
 	url := c.endpoint + "/shipping-tariffs"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("%w: client decode", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var tariff ShippingTariff
@@ -77,12 +71,16 @@ func (c *EcomClient) CreateShippingTariff(ctx context.Context, req *CreateShippi
 // GetShippingTariffs returns a list of all shipping tariffs.
 func (c *EcomClient) GetShippingTariffs(ctx context.Context) ([]*ShippingTariff, error) {
 	uri := c.endpoint + "/shipping-tariffs"
-	res, err := c.request(http.MethodGet, uri, nil)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container ShippingTariffContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("get price list response decode failed: %w", err)
@@ -7,15 +7,13 @@ import (
 	"net/http"
 	"strings"
 	"time"
-
-	"github.com/pkg/errors"
 )
 
 // UserContainer object
 type UserContainer struct {
 	Object string          `json:"object"`
 	Data   []*UserResponse `json:"data"`
-	Links  interface{}     `json:"links,omitempty"`
+	Links  *Links          `json:"links,omitempty"`
 }
 
 // CreateUserRequest request body
@@ -50,20 +48,14 @@ func (c *EcomClient) CreateUser(ctx context.Context, u *CreateUserRequest) (*Use
 
 	uri := c.endpoint + "/users"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, uri, body)
+	res, err := c.request(ctx, http.MethodPost, uri, body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request failed", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("%w: client decode error", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var user UserResponse
@@ -75,20 +67,50 @@ func (c *EcomClient) CreateUser(ctx context.Context, u *CreateUserRequest) (*Use
 
 // GetUsers calls the API Service to retreieve a list of users.
 func (c *EcomClient) GetUsers(ctx context.Context) ([]*UserResponse, error) {
-	uri := c.endpoint + "/users"
-	res, err := c.request(http.MethodGet, uri, nil)
-	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
-	}
-	defer res.Body.Close()
+	return c.Users(nil).All(ctx)
+}
 
-	if res.StatusCode >= 400 {
-		return nil, fmt.Errorf("%s: %w", res.Status, err)
+// UserIterator iterates over users, fetching a page at a time.
+type UserIterator struct {
+	p   *pager
+	buf []*UserResponse
+}
+
+// Users returns an iterator over users. opts may be nil.
+func (c *EcomClient) Users(opts *ListOptions) *UserIterator {
+	return &UserIterator{p: newPager(c, c.endpoint+"/users", opts)}
+}
+
+// Next returns the next user. It returns ErrDone when there are no
+// more.
+func (it *UserIterator) Next(ctx context.Context) (*UserResponse, error) {
+	for len(it.buf) == 0 {
+		if err := it.p.fetch(ctx, &it.buf); err != nil {
+			return nil, err
+		}
+		if n := len(it.buf); n > 0 {
+			it.p.cursor(it.buf[n-1].ID)
+		}
 	}
+	if err := it.p.take(); err != nil {
+		return nil, err
+	}
+	v := it.buf[0]
+	it.buf = it.buf[1:]
+	return v, nil
+}
 
-	var userContainer UserContainer
-	if err := json.NewDecoder(res.Body).Decode(&userContainer); err != nil {
-		return nil, errors.Wrapf(err, "json decode url=%q", uri)
+// All returns the remaining users.
+func (it *UserIterator) All(ctx context.Context) ([]*UserResponse, error) {
+	list := make([]*UserResponse, 0, 16)
+	for {
+		v, err := it.Next(ctx)
+		if err == ErrDone {
+			return list, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
 	}
-	return userContainer.Data, nil
 }
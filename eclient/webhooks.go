@@ -66,20 +66,14 @@ func (c *EcomClient) CreateWebhook(ctx context.Context, p *CreateWebhookRequest)
 
 	url := c.endpoint + "/webhooks"
 	body := strings.NewReader(string(request))
-	res, err := c.request(http.MethodPost, url, body)
+	res, err := c.request(ctx, http.MethodPost, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("%w: request", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		dec := json.NewDecoder(res.Body)
-		dec.DisallowUnknownFields()
-		if err := dec.Decode(&e); err != nil {
-			return nil, fmt.Errorf("%w: client decode", err)
-		}
-		return nil, fmt.Errorf("status: %d, code: %s, message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, nil)
 	}
 
 	var webhook WebhookResponse
@@ -92,17 +86,14 @@ func (c *EcomClient) CreateWebhook(ctx context.Context, p *CreateWebhookRequest)
 // GetWebhook calls the API service to retrive a single webhook.
 func (c *EcomClient) GetWebhook(ctx context.Context, webhookID string) (*WebhookResponse, error) {
 	url := c.endpoint + "/webhooks/" + webhookID
-	res, err := c.request(http.MethodGet, url, nil)
+	res, err := c.request(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 400 {
-		return nil, ErrBadRequest
-	}
-	if res.StatusCode == 404 {
-		return nil, ErrWebhookNotFound
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, ErrWebhookNotFound)
 	}
 
 	var w WebhookResponse
@@ -115,12 +106,16 @@ func (c *EcomClient) GetWebhook(ctx context.Context, webhookID string) (*Webhook
 // GetWebhooks returns a list of all webhooks.
 func (c *EcomClient) GetWebhooks(ctx context.Context) ([]*WebhookResponse, error) {
 	uri := c.endpoint + "/webhooks"
-	res, err := c.request(http.MethodGet, uri, nil)
+	res, err := c.request(ctx, http.MethodGet, uri, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode >= 400 {
+		return nil, apiError(res, nil)
+	}
+
 	var container WebhookContainer
 	if err := json.NewDecoder(res.Body).Decode(&container); err != nil {
 		return nil, fmt.Errorf("decode failed: %w", err)
@@ -137,18 +132,14 @@ func (c *EcomClient) UpdateWebhook(ctx context.Context, webhookID string, req *U
 
 	body := strings.NewReader(string(request))
 	url := c.endpoint + "/webhooks/" + webhookID
-	res, err := c.request(http.MethodPatch, url, body)
+	res, err := c.request(ctx, http.MethodPatch, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
 	if res.StatusCode >= 400 {
-		var e badRequestResponse
-		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
-			return nil, fmt.Errorf("decode: %w", err)
-		}
-		return nil, fmt.Errorf("Status: %d, Code: %s, Message: %s", e.Status, e.Code, e.Message)
+		return nil, apiError(res, ErrWebhookNotFound)
 	}
 
 	var w WebhookResponse
@@ -161,15 +152,14 @@ func (c *EcomClient) UpdateWebhook(ctx context.Context, webhookID string, req *U
 // DeleteWebhook calls the API service to delete a webhook
 func (c *EcomClient) DeleteWebhook(ctx context.Context, webhookID string) error {
 	url := c.endpoint + "/webhooks/" + webhookID
-	res, err := c.request(http.MethodDelete, url, nil)
+	res, err := c.request(ctx, http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("request: %w", err)
 	}
 	defer res.Body.Close()
 
-	if res.StatusCode == 404 {
-		return ErrWebhookNotFound
+	if res.StatusCode >= 400 {
+		return apiError(res, ErrWebhookNotFound)
 	}
-
 	return nil
 }
@@ -11,7 +11,7 @@ type InventoryBatchContainerYAML struct {
 type InventoryBatchYAML struct {
 	SKU         string `yaml:"sku"`
 	Onhand      int    `yaml:"onhand"`
-	Overselling bool   `yaml:"overeselling"`
+	Overselling bool   `yaml:"overselling"`
 }
 
 // A CatalogYAML contains a single root node of the catalog.
@@ -35,7 +35,7 @@ type ProductContainerYAML struct {
 // ProductImageApplyYAML contains the product image data.
 type ProductImageApplyYAML struct {
 	Path  string `yaml:"path"`
-	Title string `yaml:"title"`
+	Title string `yaml:"title,omitempty"`
 }
 
 // PriceYAML YAML price
@@ -51,7 +51,7 @@ type ProductApplyYAML struct {
 	Name    string                   `yaml:"name"`
 	Images  []*ProductImageApplyYAML `yaml:"images"`
 	Prices  map[string][]PriceYAML   `yaml:"prices"`
-	Content interface{}              `yaml:"content"`
+	Content interface{}              `yaml:"content,omitempty"`
 }
 
 // ProductYAML contains all the fields that comprise a product in the catalog.
@@ -0,0 +1,331 @@
+package eclienttest
+
+import (
+	"net/http"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+var webhookEvents = map[string]bool{
+	"service.started": true,
+	"address.created": true,
+	"address.updated": true,
+	"user.created":    true,
+	"order.created":   true,
+	"order.updated":   true,
+}
+
+func (s *Server) createUser(role, email, firstname, lastname string) *eclient.UserResponse {
+	t := now()
+	u := eclient.UserResponse{
+		Object:    "user",
+		ID:        s.newID(),
+		UID:       randomHex(14),
+		Role:      role,
+		Email:     email,
+		Firstname: firstname,
+		Lastname:  lastname,
+		Created:   t,
+		Modified:  t,
+	}
+	s.users[u.ID] = &u
+	return &u
+}
+
+func (s *Server) createDevKey(userID string) *eclient.DevKeyResponse {
+	t := now()
+	k := eclient.DevKeyResponse{
+		Object:   "developer_key",
+		ID:       s.newID(),
+		UserID:   userID,
+		Key:      randomHex(28),
+		Created:  t,
+		Modified: t,
+	}
+	s.devKeys[k.ID] = &k
+	return &k
+}
+
+func (s *Server) handleUsers(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreateUserRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		for _, u := range s.users {
+			if u.Email == req.Email {
+				writeError(w, http.StatusConflict, "users/user-exists", "user with this email already exists")
+				return
+			}
+		}
+		role := req.Role
+		if role == "" {
+			role = "customer"
+		}
+		u := s.createUser(role, req.Email, req.Firstname, req.Lastname)
+		if req.Password != "" {
+			s.passwords[u.ID] = req.Password
+		}
+		writeJSON(w, http.StatusCreated, u)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.users))
+		for k := range s.users {
+			ids = append(ids, k)
+		}
+		writePage(w, r, sortedIDs(ids), func(id string) interface{} { return s.users[id] })
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleAdmins(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req struct {
+			Email     string `json:"email"`
+			Firstname string `json:"firstname"`
+			Lastname  string `json:"lastname"`
+		}
+		if !decode(w, r, &req) {
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.createUser("admin", req.Email, req.Firstname, req.Lastname))
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0)
+		for k, u := range s.users {
+			if u.Role == "admin" {
+				ids = append(ids, k)
+			}
+		}
+		admins := make([]*eclient.UserResponse, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			admins = append(admins, s.users[k])
+		}
+		writeJSON(w, http.StatusOK, admins)
+	case id != "" && r.Method == http.MethodDelete:
+		u, ok := s.users[id]
+		if !ok || u.Role != "admin" {
+			writeError(w, http.StatusNotFound, "admins/admin-not-found", "administrator not found")
+			return
+		}
+		delete(s.users, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleAddresses(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreateAddressRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if _, ok := s.users[req.UserID]; !ok {
+			writeError(w, http.StatusNotFound, "users/user-not-found", "user not found")
+			return
+		}
+		t := now()
+		a := eclient.Address{
+			Object:      "address",
+			ID:          s.newID(),
+			UserID:      req.UserID,
+			Typ:         req.Type,
+			ContactName: req.ContactName,
+			Addr1:       req.Addr1,
+			Addr2:       req.Addr2,
+			City:        req.City,
+			County:      req.County,
+			Postcode:    req.Postcode,
+			CountryCode: req.CountryCode,
+			Created:     t,
+			Modified:    t,
+		}
+		s.addresses[a.ID] = &a
+		writeJSON(w, http.StatusCreated, &a)
+	case id == "" && r.Method == http.MethodGet:
+		userID := r.URL.Query().Get("user_id")
+		ids := make([]string, 0)
+		for k, a := range s.addresses {
+			if a.UserID == userID {
+				ids = append(ids, k)
+			}
+		}
+		data := make([]*eclient.Address, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.addresses[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		a, ok := s.addresses[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "addresses/address-not-found", "address not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, a)
+		case http.MethodPatch:
+			var req eclient.UpdateAddressRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			if req.Type != nil {
+				a.Typ = *req.Type
+			}
+			if req.ContactName != nil {
+				a.ContactName = *req.ContactName
+			}
+			if req.Addr1 != nil {
+				a.Addr1 = *req.Addr1
+			}
+			if req.Addr2 != nil {
+				a.Addr2 = req.Addr2
+			}
+			if req.City != nil {
+				a.City = *req.City
+			}
+			if req.County != nil {
+				a.County = req.County
+			}
+			if req.Postcode != nil {
+				a.Postcode = *req.Postcode
+			}
+			if req.CountryCode != nil {
+				a.CountryCode = *req.CountryCode
+			}
+			a.Modified = now()
+			writeJSON(w, http.StatusOK, a)
+		case http.MethodDelete:
+			delete(s.addresses, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleDevKeys(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.DevKeyRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if _, ok := s.users[req.UserID]; !ok {
+			writeError(w, http.StatusNotFound, "users/user-not-found", "user not found")
+			return
+		}
+		writeJSON(w, http.StatusCreated, s.createDevKey(req.UserID))
+	case id == "" && r.Method == http.MethodGet:
+		userID := r.URL.Query().Get("user_id")
+		ids := make([]string, 0)
+		for k, d := range s.devKeys {
+			if d.UserID == userID {
+				ids = append(ids, k)
+			}
+		}
+		data := make([]*eclient.DevKeyResponse, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.devKeys[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "" && r.Method == http.MethodDelete:
+		if _, ok := s.devKeys[id]; !ok {
+			writeError(w, http.StatusNotFound, "developer-keys/developer-key-not-found", "developer key not found")
+			return
+		}
+		delete(s.devKeys, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// validEvents responds with an error and returns false if any of the
+// events is not a known event type.
+func validEvents(w http.ResponseWriter, events []string) bool {
+	for _, e := range events {
+		if !webhookEvents[e] {
+			writeError(w, http.StatusBadRequest, "webhooks/event-type-not-found", "event type "+e+" not found")
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) handleWebhooks(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreateWebhookRequest
+		if !decode(w, r, &req) || !validEvents(w, req.Events.Data) {
+			return
+		}
+		for _, h := range s.webhooks {
+			if h.URL == req.URL {
+				writeError(w, http.StatusConflict, "webhooks/webhook-exists", "webhook with this url already exists")
+				return
+			}
+		}
+		t := now()
+		h := eclient.WebhookResponse{
+			Object:     "webhook",
+			ID:         s.newID(),
+			SigningKey: "whsec_" + randomHex(16),
+			URL:        req.URL,
+			Events:     req.Events.Data,
+			Enabled:    true,
+			Created:    t,
+			Modified:   t,
+		}
+		s.webhooks[h.ID] = &h
+		writeJSON(w, http.StatusCreated, &h)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.webhooks))
+		for k := range s.webhooks {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.WebhookResponse, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.webhooks[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		h, ok := s.webhooks[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "webhooks/webhook-not-found", "webhook not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, h)
+		case http.MethodPatch:
+			var req eclient.UpdateWebhookRequest
+			if !decode(w, r, &req) || !validEvents(w, req.Events.Data) {
+				return
+			}
+			for _, o := range s.webhooks {
+				if o.ID != id && o.URL == req.URL {
+					writeError(w, http.StatusConflict, "webhooks/webhook-exists", "webhook with this url already exists")
+					return
+				}
+			}
+			h.URL = req.URL
+			h.Events = req.Events.Data
+			h.Enabled = req.Enabled
+			h.Modified = now()
+			writeJSON(w, http.StatusOK, h)
+		case http.MethodDelete:
+			delete(s.webhooks, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
@@ -0,0 +1,236 @@
+package eclienttest
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/ecommerce-builder/ecom-cli-tool/configmgr"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+// signingKey signs the ID tokens issued by the server. eclient never
+// verifies the signature, it only reads the expiry.
+var signingKey = []byte("eclienttest")
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// issueTokens returns a new ID and refresh token pair for the user.
+func (s *Server) issueTokens(userID string) *configmgr.TokenAndRefreshToken {
+	claims := struct {
+		jwt.StandardClaims
+		UserID string `json:"user_id,omitempty"`
+		Role   string `json:"role,omitempty"`
+		Email  string `json:"email,omitempty"`
+	}{
+		StandardClaims: jwt.StandardClaims{
+			Subject:   userID,
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: time.Now().Add(s.TokenTTL).Unix(),
+			Id:        randomHex(8),
+		},
+	}
+	if u, ok := s.users[userID]; ok {
+		claims.UserID, claims.Role, claims.Email = u.UID, u.Role, u.Email
+	}
+	idToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(signingKey)
+	refreshToken := randomHex(32)
+	s.idTokens[idToken] = userID
+	s.refreshTokens[refreshToken] = userID
+	return &configmgr.TokenAndRefreshToken{
+		IDToken:      idToken,
+		RefreshToken: refreshToken,
+	}
+}
+
+// authorized reports whether the request carries a current ID token.
+func (s *Server) authorized(r *http.Request) bool {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return false
+	}
+	token := strings.TrimPrefix(auth, "Bearer ")
+	if _, ok := s.idTokens[token]; !ok {
+		return false
+	}
+	var claims jwt.StandardClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return signingKey, nil
+	})
+	return err == nil
+}
+
+func (s *Server) config(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, eclient.ConfigContainerResponse{
+		Object: "config",
+		FirebaseConfig: &eclient.FirebaseConfigResponse{
+			APIKEY:     s.APIKey,
+			AuthDomain: "eclienttest.firebaseapp.com",
+			ProjectID:  "eclienttest",
+		},
+	})
+}
+
+func (s *Server) sysInfo(w http.ResponseWriter, r *http.Request, id string) {
+	if r.Method != http.MethodGet || id != "" {
+		methodNotAllowed(w, r)
+		return
+	}
+	var info eclient.SysInfo
+	info.APIVersion = "eclienttest"
+	info.Env.Goog.ProjectID = "eclienttest"
+	info.Env.Firebase.APIKEY = s.APIKey
+	info.Env.Firebase.ProjectID = "eclienttest"
+	info.Env.App.AppEndpoint = s.URL
+	info.Env.App.AppRootEmail = s.Root.Email
+	writeJSON(w, http.StatusOK, &info)
+}
+
+func (s *Server) signInWithDevKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+	var req struct {
+		Key string `json:"key"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	for _, k := range s.devKeys {
+		if k.Key == req.Key {
+			token := "custom-" + randomHex(16)
+			s.customTokens[token] = k.UserID
+			writeJSON(w, http.StatusCreated, struct {
+				CustomToken string                `json:"custom_token"`
+				User        *eclient.UserResponse `json:"user"`
+			}{token, s.users[k.UserID]})
+			return
+		}
+	}
+	writeError(w, http.StatusUnauthorized, "auth/invalid-developer-key", "developer key not recognised")
+}
+
+// googleError responds in the format used by the Google identity
+// services.
+func googleError(w http.ResponseWriter, status int, message string) {
+	var body struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	body.Error.Code = status
+	body.Error.Message = message
+	body.Error.Status = "INVALID_ARGUMENT"
+	writeJSON(w, status, &body)
+}
+
+func (s *Server) exchangeRefreshToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+	if r.URL.Query().Get("key") != s.APIKey {
+		googleError(w, http.StatusBadRequest, "API key not valid")
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		googleError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	userID, ok := s.refreshTokens[r.PostForm.Get("refresh_token")]
+	if r.PostForm.Get("grant_type") != "refresh_token" || !ok {
+		googleError(w, http.StatusBadRequest, "INVALID_REFRESH_TOKEN")
+		return
+	}
+	tar := s.issueTokens(userID)
+	writeJSON(w, http.StatusOK, map[string]string{
+		"expires_in":    "3600",
+		"token_type":    "Bearer",
+		"refresh_token": tar.RefreshToken,
+		"id_token":      tar.IDToken,
+		"user_id":       userID,
+		"project_id":    "eclienttest",
+	})
+}
+
+func (s *Server) verifyCustomToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+	if r.URL.Query().Get("key") != s.APIKey {
+		googleError(w, http.StatusBadRequest, "API key not valid")
+		return
+	}
+	var req struct {
+		Token string `json:"token"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	userID, ok := s.customTokens[req.Token]
+	if !ok {
+		googleError(w, http.StatusBadRequest, "INVALID_CUSTOM_TOKEN")
+		return
+	}
+	delete(s.customTokens, req.Token)
+	tar := s.issueTokens(userID)
+	writeJSON(w, http.StatusOK, map[string]string{
+		"kind":         "identitytoolkit#VerifyCustomTokenResponse",
+		"idToken":      tar.IDToken,
+		"refreshToken": tar.RefreshToken,
+		"expiresIn":    "3600",
+	})
+}
+
+func (s *Server) verifyPassword(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+	if r.URL.Query().Get("key") != s.APIKey {
+		googleError(w, http.StatusBadRequest, "API key not valid")
+		return
+	}
+	var req struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+	if !decode(w, r, &req) {
+		return
+	}
+	for id, u := range s.users {
+		if u.Email != req.Email {
+			continue
+		}
+		if p, ok := s.passwords[id]; !ok || p != req.Password {
+			googleError(w, http.StatusBadRequest, "INVALID_PASSWORD")
+			return
+		}
+		tar := s.issueTokens(id)
+		writeJSON(w, http.StatusOK, map[string]string{
+			"kind":         "identitytoolkit#VerifyPasswordResponse",
+			"localId":      u.UID,
+			"email":        u.Email,
+			"idToken":      tar.IDToken,
+			"refreshToken": tar.RefreshToken,
+			"expiresIn":    "3600",
+		})
+		return
+	}
+	googleError(w, http.StatusBadRequest, "EMAIL_NOT_FOUND")
+}
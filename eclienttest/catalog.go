@@ -0,0 +1,673 @@
+package eclienttest
+
+import (
+	"net/http"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+func (s *Server) productNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "products/product-not-found", "product not found")
+}
+
+func (s *Server) handleProducts(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.ProductRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		for _, p := range s.products {
+			if p.SKU == req.SKU {
+				writeError(w, http.StatusConflict, "products/product-exists", "product with this sku already exists")
+				return
+			}
+		}
+		t := now()
+		p := eclient.ProductResponse{
+			Object:   "product",
+			ID:       s.newID(),
+			Path:     req.Path,
+			SKU:      req.SKU,
+			Name:     req.Name,
+			Created:  t,
+			Modified: t,
+		}
+		s.products[p.ID] = &p
+		inv := eclient.Inventory{
+			Object:      "inventory",
+			ID:          s.newID(),
+			ProductID:   p.ID,
+			ProductPath: p.Path,
+			ProductSKU:  p.SKU,
+			Created:     t,
+			Modified:    t,
+		}
+		s.inventory[inv.ID] = &inv
+		writeJSON(w, http.StatusCreated, &p)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.products))
+		for k := range s.products {
+			ids = append(ids, k)
+		}
+		writePage(w, r, sortedIDs(ids), func(id string) interface{} { return s.products[id] })
+	case id != "" && r.Method == http.MethodHead:
+		// eclient.ProductExists checks by SKU, so accept either.
+		for _, p := range s.products {
+			if p.ID == id || p.SKU == id {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	case id != "":
+		p, ok := s.products[id]
+		if !ok {
+			s.productNotFound(w)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, p)
+		case http.MethodPut:
+			var req eclient.ProductRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			for _, o := range s.products {
+				if o.ID != id && o.SKU == req.SKU {
+					writeError(w, http.StatusConflict, "products/product-exists", "product with this sku already exists")
+					return
+				}
+			}
+			p.Path, p.SKU, p.Name = req.Path, req.SKU, req.Name
+			p.Modified = now()
+			writeJSON(w, http.StatusOK, p)
+		case http.MethodDelete:
+			s.deleteProduct(id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+// deleteProduct removes the product and everything that refers to it.
+func (s *Server) deleteProduct(id string) {
+	delete(s.products, id)
+	for k, v := range s.images {
+		if v.ProductID == id {
+			delete(s.images, k)
+		}
+	}
+	for k, v := range s.prices {
+		if v.ProductID == id {
+			delete(s.prices, k)
+		}
+	}
+	for k, v := range s.inventory {
+		if v.ProductID == id {
+			delete(s.inventory, k)
+		}
+	}
+	rels := s.relations[:0]
+	for _, v := range s.relations {
+		if v.ProductID != id {
+			rels = append(rels, v)
+		}
+	}
+	s.relations = rels
+}
+
+func (s *Server) handleCategoriesTree(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" {
+		methodNotAllowed(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		var req eclient.CategoryRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		s.setTree(&req)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodGet:
+		if s.tree == nil {
+			writeError(w, http.StatusNotFound, "categories/categories-tree-not-found", "categories tree not found")
+			return
+		}
+		byPath := make(map[string]string, len(s.categories))
+		for _, c := range s.categories {
+			byPath[c.Path] = c.ID
+		}
+		writeJSON(w, http.StatusOK, treeResponse(s.tree, s.tree.Segment, byPath))
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func treeResponse(n *eclient.CategoryRequest, path string, ids map[string]string) *eclient.CategoryTreeResponse {
+	children := make([]*eclient.CategoryTreeResponse, 0, len(n.Categories))
+	for _, c := range n.Categories {
+		children = append(children, treeResponse(c, path+"/"+c.Segment, ids))
+	}
+	return &eclient.CategoryTreeResponse{
+		Object:  "category",
+		ID:      ids[path],
+		Segment: n.Segment,
+		Name:    n.Name,
+		Categories: &eclient.CategoriesContainerResponse{
+			Object: "list",
+			Data:   children,
+		},
+	}
+}
+
+// setTree replaces the categories tree, keeping the IDs of categories
+// whose path is unchanged, and drops relations to removed categories.
+func (s *Server) setTree(tree *eclient.CategoryRequest) {
+	old := make(map[string]*eclient.Category, len(s.categories))
+	for _, c := range s.categories {
+		old[c.Path] = c
+	}
+
+	var cats []*eclient.Category
+	var walk func(n *eclient.CategoryRequest, path string, depth, lft int) int
+	walk = func(n *eclient.CategoryRequest, path string, depth, lft int) int {
+		t := now()
+		c := eclient.Category{
+			Object:   "category",
+			Segment:  n.Segment,
+			Path:     path,
+			Name:     n.Name,
+			Lft:      lft,
+			Depth:    depth,
+			Created:  t,
+			Modified: t,
+		}
+		if o, ok := old[path]; ok {
+			c.ID, c.Created = o.ID, o.Created
+		} else {
+			c.ID = s.newID()
+		}
+		cats = append(cats, &c)
+		next := lft + 1
+		for _, child := range n.Categories {
+			next = walk(child, path+"/"+child.Segment, depth+1, next) + 1
+		}
+		c.Rgt = next
+		return next
+	}
+	walk(tree, tree.Segment, 0, 1)
+
+	s.tree = tree
+	s.categories = cats
+
+	valid := make(map[string]bool, len(cats))
+	for _, c := range cats {
+		valid[c.ID] = true
+	}
+	rels := s.relations[:0]
+	for _, v := range s.relations {
+		if valid[v.CategoryID] {
+			rels = append(rels, v)
+		}
+	}
+	s.relations = rels
+}
+
+func (s *Server) handleCategories(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" {
+		methodNotAllowed(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		data := s.categories
+		if data == nil {
+			data = []*eclient.Category{}
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case http.MethodDelete:
+		// purges the catalog
+		s.tree = nil
+		s.categories = nil
+		s.relations = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleProductsCategories(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" {
+		methodNotAllowed(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		data := s.relations
+		if data == nil {
+			data = []*eclient.ProductCategoryResponse{}
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case http.MethodPut:
+		var req eclient.CreateProductsCategoriesContainer
+		if !decode(w, r, &req) {
+			return
+		}
+		cats := make(map[string]*eclient.Category, len(s.categories))
+		for _, c := range s.categories {
+			cats[c.ID] = c
+		}
+		rels := make([]*eclient.ProductCategoryResponse, 0, len(req.Data))
+		pri := make(map[string]int)
+		for _, v := range req.Data {
+			p, ok := s.products[v.ProductID]
+			if !ok {
+				s.productNotFound(w)
+				return
+			}
+			c, ok := cats[v.CategoryID]
+			if !ok {
+				writeError(w, http.StatusNotFound, "categories/category-not-found", "category not found")
+				return
+			}
+			pri[c.ID]++
+			t := now()
+			rels = append(rels, &eclient.ProductCategoryResponse{
+				Object:       "product_category",
+				ID:           s.newID(),
+				ProductID:    p.ID,
+				ProductPath:  p.Path,
+				ProductSKU:   p.SKU,
+				ProductName:  p.Name,
+				CategoryID:   c.ID,
+				CategoryPath: c.Path,
+				Pri:          pri[c.ID],
+				Created:      t,
+				Modified:     t,
+			})
+		}
+		s.relations = rels
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		s.relations = nil
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleImages(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" {
+		methodNotAllowed(w, r)
+		return
+	}
+	productID := r.URL.Query().Get("product_id")
+	p, ok := s.products[productID]
+	if !ok {
+		s.productNotFound(w)
+		return
+	}
+	switch r.Method {
+	case http.MethodPost:
+		var req eclient.ImageRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		t := now()
+		img := eclient.ImageResponse{
+			Object:      "image",
+			ID:          s.newID(),
+			ProductID:   p.ID,
+			ProductPath: p.Path,
+			ProducutSKU: p.SKU,
+			Path:        req.Path,
+			GSURL:       "gs://eclienttest/" + req.Path,
+			Created:     t,
+			Modified:    t,
+		}
+		s.images[img.ID] = &img
+		writeJSON(w, http.StatusCreated, &img)
+	case http.MethodGet:
+		ids := make([]string, 0)
+		for k, v := range s.images {
+			if v.ProductID == productID {
+				ids = append(ids, k)
+			}
+		}
+		data := make([]*eclient.ImageResponse, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.images[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case http.MethodDelete:
+		for k, v := range s.images {
+			if v.ProductID == productID {
+				delete(s.images, k)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handlePrices(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" {
+		methodNotAllowed(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		ids := make([]string, 0, len(s.prices))
+		for k := range s.prices {
+			ids = append(ids, k)
+		}
+		writePage(w, r, sortedIDs(ids), func(id string) interface{} { return s.prices[id] })
+	case http.MethodPut:
+		q := r.URL.Query()
+		p, ok := s.products[q.Get("product_id")]
+		if !ok {
+			s.productNotFound(w)
+			return
+		}
+		pl, ok := s.priceLists[q.Get("price_list_id")]
+		if !ok {
+			writeError(w, http.StatusNotFound, "price-lists/price-list-not-found", "price list not found")
+			return
+		}
+		var req eclient.PricesContainerRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		for k, v := range s.prices {
+			if v.ProductID == p.ID && v.PriceListID == pl.ID {
+				delete(s.prices, k)
+			}
+		}
+		data := make([]*eclient.Price, 0, len(req.Data))
+		for _, v := range req.Data {
+			t := now()
+			price := eclient.Price{
+				Object:        "price",
+				ID:            s.newID(),
+				ProductID:     p.ID,
+				ProductPath:   p.Path,
+				ProductSKU:    p.SKU,
+				PriceListID:   pl.ID,
+				PriceListCode: pl.PriceListCode,
+				Break:         v.Break,
+				UnitPrice:     v.UnitPrice,
+				Created:       t,
+				Modified:      t,
+			}
+			s.prices[price.ID] = &price
+			data = append(data, &price)
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) priceListCodeExists(w http.ResponseWriter, code, except string) bool {
+	for _, pl := range s.priceLists {
+		if pl.ID != except && pl.PriceListCode == code {
+			writeError(w, http.StatusConflict, "price-lists/price-list-code-exists", "price list code already exists")
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) handlePriceLists(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreatePriceListRequest
+		if !decode(w, r, &req) || s.priceListCodeExists(w, req.PriceListCode, "") {
+			return
+		}
+		t := now()
+		pl := eclient.PriceList{
+			Object:        "price_list",
+			ID:            s.newID(),
+			PriceListCode: req.PriceListCode,
+			CurrencyCode:  req.CurrencyCode,
+			Strategy:      req.Strategy,
+			IncTax:        req.IncTax,
+			Name:          req.Name,
+			Description:   req.Description,
+			Created:       t,
+			Modified:      t,
+		}
+		s.priceLists[pl.ID] = &pl
+		writeJSON(w, http.StatusCreated, &pl)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.priceLists))
+		for k := range s.priceLists {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.PriceList, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.priceLists[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		pl, ok := s.priceLists[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "price-lists/price-list-not-found", "price list not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, pl)
+		case http.MethodPut:
+			var req eclient.UpdatePriceListRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			if req.PriceListCode != "" {
+				if s.priceListCodeExists(w, req.PriceListCode, id) {
+					return
+				}
+				pl.PriceListCode = req.PriceListCode
+			}
+			if req.CurrencyCode != "" {
+				pl.CurrencyCode = req.CurrencyCode
+			}
+			if req.Strategy != "" {
+				pl.Strategy = req.Strategy
+			}
+			if req.Name != "" {
+				pl.Name = req.Name
+			}
+			if req.Description != "" {
+				pl.Description = req.Description
+			}
+			pl.IncTax = req.IncTax
+			pl.Modified = now()
+			writeJSON(w, http.StatusOK, pl)
+		case http.MethodDelete:
+			delete(s.priceLists, id)
+			for k, v := range s.prices {
+				if v.PriceListID == id {
+					delete(s.prices, k)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleInventory(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.inventory))
+		for k := range s.inventory {
+			ids = append(ids, k)
+		}
+		writePage(w, r, sortedIDs(ids), func(id string) interface{} { return s.inventory[id] })
+	case id != "":
+		inv, ok := s.inventory[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "inventory/inventory-not-found", "inventory not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, inv)
+		case http.MethodPatch:
+			var req eclient.UpdateInventoryRequest
+			if !decode(w, r, &req) {
+				return
+			}
+			if req.Onhand != nil {
+				inv.Onhand = *req.Onhand
+			}
+			if req.Overselling != nil {
+				inv.Overselling = *req.Overselling
+			}
+			inv.Modified = now()
+			writeJSON(w, http.StatusOK, inv)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleInventoryBatch(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" || r.Method != http.MethodPatch {
+		methodNotAllowed(w, r)
+		return
+	}
+	var req eclient.InventoryBatchUpdateContainer
+	if !decode(w, r, &req) {
+		return
+	}
+	byProduct := make(map[string]*eclient.Inventory, len(s.inventory))
+	for _, inv := range s.inventory {
+		byProduct[inv.ProductID] = inv
+	}
+
+	// validate the whole batch before applying any of it
+	for _, v := range req.Data {
+		if v.ProductID == nil || byProduct[*v.ProductID] == nil {
+			s.productNotFound(w)
+			return
+		}
+	}
+	data := make([]*eclient.Inventory, 0, len(req.Data))
+	for _, v := range req.Data {
+		inv := byProduct[*v.ProductID]
+		if v.Onhand != nil {
+			inv.Onhand = *v.Onhand
+		}
+		if v.Overselling != nil {
+			inv.Overselling = *v.Overselling
+		}
+		inv.Modified = now()
+		data = append(data, inv)
+	}
+	writeJSON(w, http.StatusOK, list(data))
+}
+
+func (s *Server) handlePPAGroups(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreatePAGroupRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		for _, g := range s.ppaGroups {
+			if g.Code == req.PPAssocGroupCode {
+				writeError(w, http.StatusConflict, "products-assocs-groups/products-assocs-group-exists", "product to product associations group already exists")
+				return
+			}
+		}
+		t := now()
+		g := eclient.PPAssocGroupResponse{
+			Object:   "pp_assoc_group",
+			ID:       s.newID(),
+			Code:     req.PPAssocGroupCode,
+			Name:     req.Name,
+			Created:  t,
+			Modified: t,
+		}
+		s.ppaGroups[g.ID] = &g
+		writeJSON(w, http.StatusCreated, &g)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.ppaGroups))
+		for k := range s.ppaGroups {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.PPAssocGroupResponse, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.ppaGroups[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		g, ok := s.ppaGroups[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "products-assocs-groups/products-assocs-group-not-found", "product to product associations group not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, g)
+		case http.MethodDelete:
+			delete(s.ppaGroups, id)
+			for k, v := range s.ppAssocs {
+				if v.PPAssocGroupID == id {
+					delete(s.ppAssocs, k)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handlePPAssocs(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		groupID := r.URL.Query().Get("pp_assoc_group_id")
+		if _, ok := s.ppaGroups[groupID]; !ok {
+			writeError(w, http.StatusNotFound, "products-assocs-groups/products-assocs-group-not-found", "product to product associations group not found")
+			return
+		}
+		ids := make([]string, 0)
+		for k, v := range s.ppAssocs {
+			if v.PPAssocGroupID == groupID {
+				ids = append(ids, k)
+			}
+		}
+		data := make([]*eclient.PPAssoc, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.ppAssocs[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "" && r.Method == http.MethodDelete:
+		if _, ok := s.ppAssocs[id]; !ok {
+			writeError(w, http.StatusNotFound, "products-assocs/products-assoc-not-found", "product to product association not found")
+			return
+		}
+		delete(s.ppAssocs, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		methodNotAllowed(w, r)
+	}
+}
@@ -0,0 +1,502 @@
+package eclienttest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+func (s *Server) promoRuleNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "promo-rules/promo-rule-not-found", "promo rule not found")
+}
+
+func (s *Server) cartNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "carts/cart-not-found", "cart not found")
+}
+
+func optional(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func (s *Server) handlePromoRules(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.PromoRuleRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		for _, p := range s.promoRules {
+			if p.PromoRuleCode == req.PromoRuleCode {
+				writeError(w, http.StatusConflict, "promo-rules/promo-rule-exists", "promo rule with this code already exists")
+				return
+			}
+		}
+		t := now()
+		p := eclient.PromoRule{
+			Object:           "promo_rule",
+			ID:               s.newID(),
+			PromoRuleCode:    req.PromoRuleCode,
+			ProductID:        optional(req.ProductID),
+			CategoryID:       optional(req.CategoryID),
+			ShippingTariffID: optional(req.ShippingTariffID),
+			Name:             req.Name,
+			StartAt:          req.StartAt,
+			EndAt:            req.EndAt,
+			Amount:           req.Amount,
+			Type:             req.Type,
+			Target:           req.Target,
+			Created:          t,
+			Modified:         t,
+		}
+		if req.TotalThreshold != 0 {
+			p.TotalThreshold = &req.TotalThreshold
+		}
+		if prod, ok := s.products[req.ProductID]; ok {
+			p.ProductPath, p.ProductSKU = &prod.Path, &prod.SKU
+		}
+		if tariff, ok := s.tariffs[req.ShippingTariffID]; ok {
+			p.ShippingTariffCode = &tariff.ShippingCode
+		}
+		s.promoRules[p.ID] = &p
+		writeJSON(w, http.StatusCreated, &p)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.promoRules))
+		for k := range s.promoRules {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.PromoRule, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.promoRules[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		p, ok := s.promoRules[id]
+		if !ok {
+			s.promoRuleNotFound(w)
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, p)
+		case http.MethodDelete:
+			delete(s.promoRules, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleTariffs(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreateShippingTariffRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		t := now()
+		tariff := eclient.ShippingTariff{
+			Object:       "shipping_tariff",
+			ID:           s.newID(),
+			CountryCode:  req.CountryCode,
+			ShippingCode: req.Shippingcode,
+			Name:         req.Name,
+			Price:        req.Price,
+			TaxCode:      req.TaxCode,
+			Created:      t,
+			Modified:     t,
+		}
+		s.tariffs[tariff.ID] = &tariff
+		writeJSON(w, http.StatusCreated, &tariff)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.tariffs))
+		for k := range s.tariffs {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.ShippingTariff, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.tariffs[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleCoupons(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreateCouponRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		p, ok := s.promoRules[req.PromoRuleID]
+		if !ok {
+			s.promoRuleNotFound(w)
+			return
+		}
+		for _, c := range s.coupons {
+			if c.CouponCode == req.CouponCode {
+				writeError(w, http.StatusConflict, "coupons/coupon-exists", "coupon with this code already exists")
+				return
+			}
+		}
+		t := now()
+		c := eclient.Coupon{
+			Object:        "coupon",
+			ID:            s.newID(),
+			CouponCode:    req.CouponCode,
+			PromoRuleID:   p.ID,
+			PromoRuleCode: p.PromoRuleCode,
+			Resuable:      req.Resuable,
+			Created:       t,
+			Modified:      t,
+		}
+		s.coupons[c.ID] = &c
+		writeJSON(w, http.StatusCreated, &c)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.coupons))
+		for k := range s.coupons {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.Coupon, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.coupons[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		c, ok := s.coupons[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "coupons/coupon-not-found", "coupon not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, c)
+		case http.MethodPatch:
+			var req struct {
+				Void *bool `json:"void"`
+			}
+			if !decode(w, r, &req) {
+				return
+			}
+			if req.Void != nil {
+				c.Void = *req.Void
+			}
+			c.Modified = now()
+			writeJSON(w, http.StatusOK, c)
+		case http.MethodDelete:
+			delete(s.coupons, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleOffers(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CreateOfferRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		p, ok := s.promoRules[req.PromoRuleID]
+		if !ok {
+			s.promoRuleNotFound(w)
+			return
+		}
+		for _, o := range s.offers {
+			if o.PromoRuleID == p.ID {
+				writeError(w, http.StatusConflict, "offers/offer-exists", "offer already exists for this promo rule")
+				return
+			}
+		}
+		t := now()
+		o := eclient.Offer{
+			Object:        "offer",
+			ID:            s.newID(),
+			PromoRuleID:   p.ID,
+			PromoRuleCode: p.PromoRuleCode,
+			Created:       t,
+			Modified:      t,
+		}
+		s.offers[o.ID] = &o
+		writeJSON(w, http.StatusCreated, &o)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.offers))
+		for k := range s.offers {
+			ids = append(ids, k)
+		}
+		data := make([]*eclient.Offer, 0, len(ids))
+		for _, k := range sortedIDs(ids) {
+			data = append(data, s.offers[k])
+		}
+		writeJSON(w, http.StatusOK, list(data))
+	case id != "":
+		o, ok := s.offers[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "offers/offer-not-found", "offer not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, o)
+		case http.MethodDelete:
+			delete(s.offers, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func (s *Server) handleCarts(w http.ResponseWriter, r *http.Request, id string) {
+	if id != "" || r.Method != http.MethodPost {
+		methodNotAllowed(w, r)
+		return
+	}
+	t := now()
+	c := eclient.Cart{
+		Object:   "cart",
+		ID:       s.newID(),
+		Created:  t,
+		Modified: t,
+	}
+	s.carts[c.ID] = &c
+	writeJSON(w, http.StatusCreated, &c)
+}
+
+// cartItems returns the products in the cart in the order they were added.
+func (s *Server) cartItems(cartID string) []*eclient.CartProduct {
+	ids := make([]string, 0)
+	for k, v := range s.cartProducts {
+		if v.CartID == cartID {
+			ids = append(ids, k)
+		}
+	}
+	data := make([]*eclient.CartProduct, 0, len(ids))
+	for _, k := range sortedIDs(ids) {
+		data = append(data, &s.cartProducts[k].CartProduct)
+	}
+	return data
+}
+
+// unitPrice returns the first unit price for the product, or zero.
+func (s *Server) unitPrice(productID string) int {
+	ids := make([]string, 0)
+	for k, v := range s.prices {
+		if v.ProductID == productID {
+			ids = append(ids, k)
+		}
+	}
+	if len(ids) == 0 {
+		return 0
+	}
+	return s.prices[sortedIDs(ids)[0]].UnitPrice
+}
+
+func (s *Server) handleCartProducts(w http.ResponseWriter, r *http.Request, id string) {
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.CartProductRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		if _, ok := s.carts[req.CartID]; !ok {
+			s.cartNotFound(w)
+			return
+		}
+		p, ok := s.products[req.ProductID]
+		if !ok {
+			s.productNotFound(w)
+			return
+		}
+		for _, v := range s.cartProducts {
+			if v.CartID == req.CartID && v.ProductID == req.ProductID {
+				writeError(w, http.StatusConflict, "carts/cart-product-exists", "product already in cart")
+				return
+			}
+		}
+		t := now()
+		cp := cartProduct{
+			CartProduct: eclient.CartProduct{
+				Object:    "cart_product",
+				ID:        s.newID(),
+				ProductID: p.ID,
+				SKU:       p.SKU,
+				Name:      p.Name,
+				Qty:       req.Qty,
+				UnitPrice: s.unitPrice(p.ID),
+				Created:   t,
+				Modified:  t,
+			},
+			CartID: req.CartID,
+		}
+		s.cartProducts[cp.ID] = &cp
+		writeJSON(w, http.StatusCreated, &cp.CartProduct)
+	case id == "" && (r.Method == http.MethodGet || r.Method == http.MethodDelete):
+		cartID := r.URL.Query().Get("cart_id")
+		if _, ok := s.carts[cartID]; !ok {
+			s.cartNotFound(w)
+			return
+		}
+		if r.Method == http.MethodGet {
+			writeJSON(w, http.StatusOK, list(s.cartItems(cartID)))
+			return
+		}
+		for k, v := range s.cartProducts {
+			if v.CartID == cartID {
+				delete(s.cartProducts, k)
+			}
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case id != "":
+		cp, ok := s.cartProducts[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "carts/cart-product-not-found", "cart product not found")
+			return
+		}
+		switch r.Method {
+		case http.MethodPatch:
+			var req struct {
+				Qty int `json:"qty"`
+			}
+			if !decode(w, r, &req) {
+				return
+			}
+			cp.Qty = req.Qty
+			cp.Modified = now()
+			writeJSON(w, http.StatusOK, &cp.CartProduct)
+		case http.MethodDelete:
+			delete(s.cartProducts, id)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
+
+func deref(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func orderAddr(a *eclient.OrderAddressRequest) eclient.OrderAddr {
+	if a == nil {
+		return eclient.OrderAddr{}
+	}
+	return eclient.OrderAddr{
+		ContactName: deref(a.ContactName),
+		Addr1:       deref(a.Addr1),
+		Addr2:       a.Addr2,
+		City:        deref(a.City),
+		County:      a.County,
+		Postcode:    deref(a.Postcode),
+		CountryCode: deref(a.CountryCode),
+	}
+}
+
+func (s *Server) handleOrders(w http.ResponseWriter, r *http.Request, id string) {
+	// POST /orders/{id}/stripecheckout
+	var action string
+	if i := strings.IndexByte(id, '/'); i >= 0 {
+		id, action = id[:i], id[i+1:]
+	}
+
+	switch {
+	case id == "" && r.Method == http.MethodPost:
+		var req eclient.OrderRequest
+		if !decode(w, r, &req) {
+			return
+		}
+		cartID := deref(req.CartID)
+		if _, ok := s.carts[cartID]; !ok {
+			s.cartNotFound(w)
+			return
+		}
+		items := s.cartItems(cartID)
+		if len(items) == 0 {
+			writeError(w, http.StatusConflict, "orders/cart-empty", "cart is empty")
+			return
+		}
+
+		t := now()
+		s.orderSeq++
+		o := eclient.Order{
+			Object:   "order",
+			ID:       s.newID(),
+			OrderID:  s.orderSeq,
+			Status:   "incomplete",
+			Payment:  "unpaid",
+			Billing:  orderAddr(req.Billing),
+			Shipping: orderAddr(req.Shipping),
+			Currency: "GBP",
+			Created:  t,
+			Modified: t,
+		}
+		o.User.ContactName = deref(req.ContactName)
+		o.User.Email = deref(req.Email)
+		o.User.UserID = deref(req.UserID)
+		for _, v := range items {
+			vat := v.UnitPrice * v.Qty / 5
+			o.Items = append(o.Items, &eclient.OrderItem{
+				Object:    "order_item",
+				ID:        s.newID(),
+				Path:      s.products[v.ProductID].Path,
+				SKU:       v.SKU,
+				Name:      v.Name,
+				Qty:       v.Qty,
+				UnitPrice: v.UnitPrice,
+				Currency:  "GBP",
+				TaxCode:   "T20",
+				VAT:       vat,
+				Created:   t,
+			})
+			o.TotalExVAT += v.UnitPrice * v.Qty
+			o.VATTotal += vat
+		}
+		o.TotalIncVAT = o.TotalExVAT + o.VATTotal
+		s.orders[o.ID] = &o
+		writeJSON(w, http.StatusCreated, &o)
+	case id == "" && r.Method == http.MethodGet:
+		ids := make([]string, 0, len(s.orders))
+		for k := range s.orders {
+			ids = append(ids, k)
+		}
+		writePage(w, r, sortedIDs(ids), func(id string) interface{} { return s.orders[id] })
+	case id != "":
+		o, ok := s.orders[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, "orders/order-not-found", "order not found")
+			return
+		}
+		switch {
+		case action == "" && r.Method == http.MethodGet:
+			writeJSON(w, http.StatusOK, o)
+		case action == "stripecheckout" && r.Method == http.MethodPost:
+			writeJSON(w, http.StatusOK, struct {
+				Object            string `json:"object"`
+				CheckoutSessionID string `json:"checkout_session_id"`
+			}{"stripe_checkout_session", "cs_test_" + randomHex(16)})
+		default:
+			methodNotAllowed(w, r)
+		}
+	default:
+		methodNotAllowed(w, r)
+	}
+}
@@ -0,0 +1,356 @@
+// Package eclienttest provides an in-memory implementation of the API
+// Service, and the Firebase identity endpoints it relies on, for testing
+// eclient and the ecom commands without network access.
+//
+//	srv := eclienttest.NewServer()
+//	defer srv.Close()
+//	client := srv.Client()
+//	products, err := client.GetProducts(ctx)
+//
+// The identity endpoints are served beneath the same paths as the
+// Firebase Auth emulator, so commands can be pointed at the server by
+// setting FIREBASE_AUTH_EMULATOR_HOST to srv.Host().
+package eclienttest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+)
+
+// Server is an in-memory API Service. All state is held in memory and
+// discarded by Close.
+type Server struct {
+	// URL is the base URL of the API Service, suitable for eclient.New.
+	URL string
+
+	// APIKey is the Firebase API key returned from /config.
+	APIKey string
+
+	// DevKey is a developer key for the root administrator, suitable
+	// for signing in with `ecom profiles create`.
+	DevKey string
+
+	// Password is the password of the root administrator, for signing
+	// in with `ecom login`.
+	Password string
+
+	// Root is the root administrator created with the server.
+	Root *eclient.UserResponse
+
+	// TokenTTL is the lifetime of issued ID tokens. It defaults to one
+	// hour, the lifetime of Firebase ID tokens.
+	TokenTTL time.Duration
+
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	seq      int
+	failures []int
+
+	// identity
+	idTokens      map[string]string // ID token -> user ID
+	refreshTokens map[string]string // refresh token -> user ID
+	customTokens  map[string]string // custom token -> user ID
+	passwords     map[string]string // user ID -> password
+
+	// accounts
+	users     map[string]*eclient.UserResponse
+	addresses map[string]*eclient.Address
+	devKeys   map[string]*eclient.DevKeyResponse
+	webhooks  map[string]*eclient.WebhookResponse
+
+	// catalog
+	products     map[string]*eclient.ProductResponse
+	tree         *eclient.CategoryRequest
+	categories   []*eclient.Category
+	relations    []*eclient.ProductCategoryResponse
+	images       map[string]*eclient.ImageResponse
+	priceLists   map[string]*eclient.PriceList
+	prices       map[string]*eclient.Price
+	inventory    map[string]*eclient.Inventory
+	ppaGroups    map[string]*eclient.PPAssocGroupResponse
+	ppAssocs     map[string]*eclient.PPAssoc
+	promoRules   map[string]*eclient.PromoRule
+	tariffs      map[string]*eclient.ShippingTariff
+	coupons      map[string]*eclient.Coupon
+	offers       map[string]*eclient.Offer
+	carts        map[string]*eclient.Cart
+	cartProducts map[string]*cartProduct
+	orders       map[string]*eclient.Order
+	orderSeq     int
+}
+
+type cartProduct struct {
+	eclient.CartProduct
+	CartID string
+}
+
+// NewServer starts and returns a new Server. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		APIKey:        "fake-api-key",
+		TokenTTL:      time.Hour,
+		idTokens:      make(map[string]string),
+		refreshTokens: make(map[string]string),
+		customTokens:  make(map[string]string),
+		passwords:     make(map[string]string),
+		users:         make(map[string]*eclient.UserResponse),
+		addresses:     make(map[string]*eclient.Address),
+		devKeys:       make(map[string]*eclient.DevKeyResponse),
+		webhooks:      make(map[string]*eclient.WebhookResponse),
+		products:      make(map[string]*eclient.ProductResponse),
+		images:        make(map[string]*eclient.ImageResponse),
+		priceLists:    make(map[string]*eclient.PriceList),
+		prices:        make(map[string]*eclient.Price),
+		inventory:     make(map[string]*eclient.Inventory),
+		ppaGroups:     make(map[string]*eclient.PPAssocGroupResponse),
+		ppAssocs:      make(map[string]*eclient.PPAssoc),
+		promoRules:    make(map[string]*eclient.PromoRule),
+		tariffs:       make(map[string]*eclient.ShippingTariff),
+		coupons:       make(map[string]*eclient.Coupon),
+		offers:        make(map[string]*eclient.Offer),
+		carts:         make(map[string]*eclient.Cart),
+		cartProducts:  make(map[string]*cartProduct),
+		orders:        make(map[string]*eclient.Order),
+	}
+
+	s.Root = s.createUser("root", "root@example.com", "Root", "Admin")
+	key := s.createDevKey(s.Root.ID)
+	s.DevKey = key.Key
+	s.Password = randomHex(8)
+	s.passwords[s.Root.ID] = s.Password
+
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Host returns the host:port of the server, suitable for the
+// FIREBASE_AUTH_EMULATOR_HOST environment variable.
+func (s *Server) Host() string {
+	u, _ := url.Parse(s.URL)
+	return u.Host
+}
+
+// Options returns the eclient options that point the identity endpoints
+// at the server.
+func (s *Server) Options() []eclient.Option {
+	return []eclient.Option{
+		eclient.WithSecureTokenURL(s.URL + "/securetoken.googleapis.com"),
+		eclient.WithIdentityToolkitURL(s.URL + "/www.googleapis.com"),
+	}
+}
+
+// Client returns an EcomClient for the server authenticated as the root
+// administrator. opts are applied after the server's own options.
+func (s *Server) Client(opts ...eclient.Option) *eclient.EcomClient {
+	c, err := eclient.New(s.URL, append(s.Options(), opts...)...)
+	if err != nil {
+		panic(err) // s.URL is always valid
+	}
+	c.SetJWT(s.Token())
+	return c
+}
+
+// Token issues a new ID token for the root administrator.
+func (s *Server) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tar := s.issueTokens(s.Root.ID)
+	return tar.IDToken
+}
+
+// ExpireTokens invalidates every ID token issued so far. Refresh tokens
+// remain valid, so clients can recover by refreshing.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.idTokens = make(map[string]string)
+}
+
+// FailNext makes the server respond to the next len(statuses) API
+// requests with the given status codes, in order, before handling
+// requests normally again. It is intended for exercising retries.
+func (s *Server) FailNext(statuses ...int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, statuses...)
+}
+
+// handler handles a request to a resource. id is the path segment
+// following the collection name, if any.
+type handler func(w http.ResponseWriter, r *http.Request, id string)
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.failures) > 0 {
+		status := s.failures[0]
+		s.failures = s.failures[1:]
+		writeError(w, status, "", http.StatusText(status))
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	switch {
+	case path == "securetoken.googleapis.com/v1/token":
+		s.exchangeRefreshToken(w, r)
+		return
+	case path == "www.googleapis.com/identitytoolkit/v3/relyingparty/verifyCustomToken":
+		s.verifyCustomToken(w, r)
+		return
+	case path == "www.googleapis.com/identitytoolkit/v3/relyingparty/verifyPassword":
+		s.verifyPassword(w, r)
+		return
+	case path == "config":
+		s.config(w, r)
+		return
+	case path == "signin-with-devkey":
+		s.signInWithDevKey(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, "auth/unauthorized", "missing or invalid bearer token")
+		return
+	}
+
+	collection, id := path, ""
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		collection, id = path[:i], path[i+1:]
+	}
+
+	routes := map[string]handler{
+		"sysinfo":                s.sysInfo,
+		"users":                  s.handleUsers,
+		"admins":                 s.handleAdmins,
+		"addresses":              s.handleAddresses,
+		"developer-keys":         s.handleDevKeys,
+		"webhooks":               s.handleWebhooks,
+		"products":               s.handleProducts,
+		"categories":             s.handleCategories,
+		"categories-tree":        s.handleCategoriesTree,
+		"products-categories":    s.handleProductsCategories,
+		"images":                 s.handleImages,
+		"prices":                 s.handlePrices,
+		"price-lists":            s.handlePriceLists,
+		"inventory":              s.handleInventory,
+		"inventory:batch-update": s.handleInventoryBatch,
+		"products-assocs-groups": s.handlePPAGroups,
+		"products-assocs":        s.handlePPAssocs,
+		"promo-rules":            s.handlePromoRules,
+		"shipping-tariffs":       s.handleTariffs,
+		"coupons":                s.handleCoupons,
+		"offers":                 s.handleOffers,
+		"carts":                  s.handleCarts,
+		"carts-products":         s.handleCartProducts,
+		"orders":                 s.handleOrders,
+	}
+	h, ok := routes[collection]
+	if !ok {
+		writeError(w, http.StatusNotFound, "", fmt.Sprintf("no route for %s /%s", r.Method, path))
+		return
+	}
+	h(w, r, id)
+}
+
+// newID returns a new unique identifier in the form of a UUID.
+func (s *Server) newID() string {
+	s.seq++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.seq)
+}
+
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with the error body used by the API Service.
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, struct {
+		Status  int    `json:"status"`
+		Code    string `json:"code,omitempty"`
+		Message string `json:"message"`
+	}{status, code, message})
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "", fmt.Sprintf("method %s not allowed on %s", r.Method, r.URL.Path))
+}
+
+// decode reads the JSON request body into v, responding with a 400 and
+// returning false if it is malformed.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "", "malformed request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func list(data interface{}) interface{} {
+	return struct {
+		Object string      `json:"object"`
+		Data   interface{} `json:"data"`
+	}{"list", data}
+}
+
+// writePage responds with a page of the items with the given ids,
+// honouring the limit and starting_after query parameters used by the
+// eclient iterators. ids must be in a stable order.
+func writePage(w http.ResponseWriter, r *http.Request, ids []string, item func(id string) interface{}) {
+	q := r.URL.Query()
+	start := 0
+	if after := q.Get("starting_after"); after != "" {
+		for i, id := range ids {
+			if id == after {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := len(ids)
+	if n, err := strconv.Atoi(q.Get("limit")); err == nil && n > 0 && start+n < end {
+		end = start + n
+	}
+	if start > end {
+		start = end
+	}
+	data := make([]interface{}, 0, end-start)
+	for _, id := range ids[start:end] {
+		data = append(data, item(id))
+	}
+	writeJSON(w, http.StatusOK, struct {
+		Object  string        `json:"object"`
+		Data    []interface{} `json:"data"`
+		HasMore bool          `json:"has_more"`
+	}{"list", data, end < len(ids)})
+}
+
+// sortedIDs returns the IDs, which sort in order of creation.
+func sortedIDs(ids []string) []string {
+	sort.Strings(ids)
+	return ids
+}
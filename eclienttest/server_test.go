@@ -0,0 +1,240 @@
+package eclienttest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
+	"github.com/ecommerce-builder/ecom-cli-tool/eclienttest"
+)
+
+// recorder is a RoundTripper that records the requests sent through it.
+type recorder struct {
+	mu   sync.Mutex
+	reqs []*url.URL
+}
+
+func (r *recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	r.reqs = append(r.reqs, req.URL)
+	r.mu.Unlock()
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func (r *recorder) requests() []*url.URL {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reqs
+}
+
+func createProducts(t *testing.T, c *eclient.EcomClient, n int) []string {
+	t.Helper()
+	skus := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		sku := fmt.Sprintf("SKU-%d", i)
+		_, err := c.CreateProduct(context.Background(), &eclient.ProductRequest{
+			SKU:  sku,
+			Path: fmt.Sprintf("sku-%d", i),
+			Name: fmt.Sprintf("Product %d", i),
+		})
+		if err != nil {
+			t.Fatalf("CreateProduct(%s): %v", sku, err)
+		}
+		skus = append(skus, sku)
+	}
+	return skus
+}
+
+func TestPaging(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	skus := createProducts(t, srv.Client(), 5)
+
+	tests := []struct {
+		name     string
+		opts     *eclient.ListOptions
+		want     int
+		requests int
+	}{
+		{"one page", nil, 5, 1},
+		{"pages of two", &eclient.ListOptions{PageSize: 2}, 5, 3},
+		{"page size of total", &eclient.ListOptions{PageSize: 5}, 5, 1},
+		{"limit", &eclient.ListOptions{PageSize: 2, Limit: 3}, 3, 2},
+		{"limit without page size", &eclient.ListOptions{Limit: 2}, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			c := srv.Client(eclient.WithTransport(rec))
+			products, err := c.Products(tt.opts).All(context.Background())
+			if err != nil {
+				t.Fatalf("All: %v", err)
+			}
+			if len(products) != tt.want {
+				t.Fatalf("got %d products, want %d", len(products), tt.want)
+			}
+			for i, p := range products {
+				if p.SKU != skus[i] {
+					t.Errorf("product %d is %s, want %s", i, p.SKU, skus[i])
+				}
+			}
+
+			reqs := rec.requests()
+			if len(reqs) != tt.requests {
+				t.Fatalf("got %d requests, want %d: %v", len(reqs), tt.requests, reqs)
+			}
+			for i, u := range reqs {
+				after := u.Query().Get("starting_after")
+				if i == 0 && after != "" {
+					t.Errorf("first request has starting_after=%s", after)
+				}
+				if i > 0 && after != products[i*tt.opts.PageSize-1].ID {
+					t.Errorf("request %d has starting_after=%s, want %s",
+						i, after, products[i*tt.opts.PageSize-1].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestFailNextRetry(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	policy := eclient.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}
+	c := srv.Client(eclient.WithRetryPolicy(policy))
+	ctx := context.Background()
+	p, err := c.CreateProduct(ctx, &eclient.ProductRequest{SKU: "A", Path: "a", Name: "A"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		failures []int
+		status   int // of the error returned, or 0 for success
+	}{
+		{"no failures", nil, 0},
+		{"retried", []int{http.StatusServiceUnavailable, http.StatusBadGateway}, 0},
+		{"too many failures", []int{503, 503, 503}, http.StatusServiceUnavailable},
+		{"not transient", []int{http.StatusInternalServerError}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv.FailNext(tt.failures...)
+			got, err := c.GetProduct(ctx, p.ID)
+			if tt.status == 0 {
+				if err != nil {
+					t.Fatalf("GetProduct: %v", err)
+				}
+				if got.SKU != "A" {
+					t.Errorf("got sku %s, want A", got.SKU)
+				}
+				return
+			}
+			var apiErr *eclient.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("got error %v, want an *eclient.APIError", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("got status %d, want %d", apiErr.StatusCode, tt.status)
+			}
+		})
+	}
+}
+
+func TestFailNextNotRetriedForPost(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	c := srv.Client(eclient.WithRetryPolicy(eclient.RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond,
+	}))
+	ctx := context.Background()
+
+	srv.FailNext(http.StatusServiceUnavailable)
+	if _, err := c.CreateProduct(ctx, &eclient.ProductRequest{SKU: "A", Path: "a", Name: "A"}); err == nil {
+		t.Fatal("CreateProduct succeeded, want the injected 503")
+	}
+	products, err := c.GetProducts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(products) != 0 {
+		t.Errorf("got %d products, want none", len(products))
+	}
+}
+
+func TestExpireTokensRefresh(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	ctx := context.Background()
+	c, err := eclient.New(srv.URL, srv.Options()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.SignInEphemeral(ctx, srv.DevKey); err != nil {
+		t.Fatalf("SignInEphemeral: %v", err)
+	}
+	stale := c.JWT()
+
+	srv.ExpireTokens()
+	if _, err := c.GetProducts(ctx); err != nil {
+		t.Fatalf("GetProducts after ExpireTokens: %v", err)
+	}
+	if c.JWT() == stale {
+		t.Error("ID token was not refreshed after a 401")
+	}
+
+	// a client with no refresh token cannot recover
+	c = srv.Client()
+	srv.ExpireTokens()
+	_, err = c.GetProducts(ctx)
+	if !errors.Is(err, eclient.ErrUnauthorized) {
+		t.Errorf("got error %v, want ErrUnauthorized", err)
+	}
+}
+
+func TestNotFound(t *testing.T) {
+	srv := eclienttest.NewServer()
+	defer srv.Close()
+	c := srv.Client()
+	ctx := context.Background()
+	const id = "00000000-0000-4000-8000-999999999999"
+
+	_, productErr := c.GetProduct(ctx, id)
+	_, priceListErr := c.GetPriceList(ctx, id)
+	tests := []struct {
+		name     string
+		err      error
+		resource error
+	}{
+		{"product", productErr, eclient.ErrProductNotFound},
+		{"delete product", c.DeleteProduct(ctx, id), eclient.ErrProductNotFound},
+		{"price list", priceListErr, eclient.ErrPriceListNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, eclient.ErrNotFound) {
+				t.Errorf("got error %v, want ErrNotFound", tt.err)
+			}
+			if !errors.Is(tt.err, tt.resource) {
+				t.Errorf("got error %v, want %v", tt.err, tt.resource)
+			}
+			var apiErr *eclient.APIError
+			if errors.As(tt.err, &apiErr) && apiErr.StatusCode != http.StatusNotFound {
+				t.Errorf("got status %d, want 404", apiErr.StatusCode)
+			}
+		})
+	}
+}
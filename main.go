@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/ecommerce-builder/ecom-cli-tool/cmd"
+	"github.com/ecommerce-builder/ecom-cli-tool/cmd/cmdutil"
 	"github.com/ecommerce-builder/ecom-cli-tool/eclient"
 )
 
@@ -13,7 +14,7 @@ var version string
 func main() {
 	cmd.Version = version
 	eclient.Version = version
-	root := cmd.NewEcomCmd()
+	root := cmd.NewEcomCmd(cmdutil.NewFactory())
 	if err := root.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "%+v\n", err)
 		os.Exit(1)
@@ -1,28 +1,8 @@
 package service
 
-import (
-	"fmt"
-	"os"
-	"time"
-)
-
 var countryCodes []string
 
-// Location contains the London timezone
-var Location *time.Location
-
-// TimeDisplayFormat is the standard format for output.
-const TimeDisplayFormat = "2006-01-02 15:04"
-
 func init() {
-	var err error
-	Location, err = time.LoadLocation("Europe/London")
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "time.LoadLocation(%q) failed: %+v",
-			"Europe/London", err.Error())
-		return
-	}
-
 	countryCodes = []string{
 		"GB - United Kingdom",
 		"AT - Austria",
@@ -67,8 +47,3 @@ func TitleFromCountryCode(match string) string {
 	}
 	return ""
 }
-
-// IntPriceToString converts integer to formatted price display string.
-func IntPriceToString(p int) string {
-	return fmt.Sprintf("£%.4f", float64(p)/10000.0)
-}